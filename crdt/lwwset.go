@@ -0,0 +1,242 @@
+package crdt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// LWWSet is the Set implementation of the LWWSet
+// It supports delta-state & op-based replication, history,
+// TTLs, metadata, batches and the garbage collection of
+// removed values
+type LWWSet struct {
+	Set lwwset.LWWSet
+}
+
+// NewLWWSet returns the Set of the given LWWSet
+func NewLWWSet(set lwwset.LWWSet) *LWWSet {
+	return &LWWSet{Set: set}
+}
+
+// Add adds the value to the LWWSet
+func (set *LWWSet) Add(value string) error {
+	var err error
+	set.Set, err = set.Set.Addition(value)
+	return err
+}
+
+// String returns the contents of the LWWSet
+func (set *LWWSet) String() string {
+	return set.Set.String()
+}
+
+// AddWithTTL adds the value to the
+// LWWSet expiring after the TTL
+func (set *LWWSet) AddWithTTL(value string, ttl time.Duration) error {
+	var err error
+	set.Set, err = set.Set.AdditionWithTTL(value, ttl)
+	return err
+}
+
+// AddWithTTLAndMetadata adds the value to the LWWSet
+// along with its Metadata expiring after the TTL
+func (set *LWWSet) AddWithTTLAndMetadata(value string, ttl time.Duration, metadata Metadata) error {
+	var err error
+	set.Set, err = set.Set.AdditionWithTTLAndMetadata(value, ttl, lwwset.Metadata(metadata))
+	return err
+}
+
+// Expire removes the values expired from the
+// LWWSet and returns the number removed
+func (set *LWWSet) Expire() int {
+	return set.Set.Expire()
+}
+
+// AddWithMetadata adds the value to the
+// LWWSet along with its Metadata
+func (set *LWWSet) AddWithMetadata(value string, metadata Metadata) error {
+	var err error
+	set.Set, err = set.Set.AdditionWithMetadata(value, lwwset.Metadata(metadata))
+	return err
+}
+
+// ListWithMetadata returns the values present
+// in the LWWSet along with their Metadata
+func (set *LWWSet) ListWithMetadata() []Element {
+	_, lwwelements := set.Set.ListWithMetadata()
+
+	elements := make([]Element, len(lwwelements))
+	for index, element := range lwwelements {
+		elements[index] = Element{Value: element.Value, Metadata: Metadata(element.Metadata)}
+	}
+	return elements
+}
+
+// ApplyBatch applies the operations with the same
+// timestamp and returns the error of each operation
+func (set *LWWSet) ApplyBatch(ops []BatchOp) []error {
+	lwwops := make([]lwwset.BatchOp[string], len(ops))
+	for index, op := range ops {
+		lwwops[index] = lwwset.BatchOp[string]{Type: lwwset.OpType(op.Type), Value: op.Value}
+	}
+
+	var errs []error
+	set.Set, errs = set.Set.ApplyBatch(lwwops)
+	return errs
+}
+
+// Remove removes the value from the LWWSet
+func (set *LWWSet) Remove(value string) error {
+	var err error
+	set.Set, err = set.Set.Removal(value)
+	return err
+}
+
+// Lookup returns if the value is present in the LWWSet
+func (set *LWWSet) Lookup(value string) (bool, error) {
+	return set.Set.Lookup(value)
+}
+
+// List returns the values present in the LWWSet
+func (set *LWWSet) List() []string {
+	_, values := set.Set.List()
+	return values
+}
+
+// Merge merges the given LWWSet into the LWWSet
+// if both were configured with the same bias
+func (set *LWWSet) Merge(other Set) error {
+	otherSet, ok := other.(*LWWSet)
+	if !ok {
+		return ErrTypeMismatch
+	}
+
+	err := set.Set.Compatible(otherSet.Set)
+	if err != nil {
+		return err
+	}
+
+	set.Set = set.Set.Join(otherSet.Set)
+	return nil
+}
+
+// Empty returns a new empty LWWSet
+func (set *LWWSet) Empty() Set {
+	return NewLWWSet(lwwset.Initialize())
+}
+
+// MarshalJSON encodes the LWWSet
+func (set *LWWSet) MarshalJSON() ([]byte, error) {
+	return set.Set.MarshalJSON()
+}
+
+// UnmarshalJSON decodes the LWWSet, it returns
+// ErrTypeMismatch for another set type's encoding
+func (set *LWWSet) UnmarshalJSON(data []byte) error {
+	err := set.Set.UnmarshalJSON(data)
+	if errors.Is(err, lwwset.ErrTypeMismatch) {
+		return fmt.Errorf("%w: %w", ErrTypeMismatch, err)
+	}
+	return err
+}
+
+// Version returns the current version of the LWWSet
+func (set *LWWSet) Version() uint64 {
+	return set.Set.Version()
+}
+
+// DeltaSince returns a LWWSet of the
+// changes made since the version
+func (set *LWWSet) DeltaSince(version uint64) Set {
+	return NewLWWSet(set.Set.DeltaSince(version))
+}
+
+// OpsSince returns the operations in the op log
+// after the offset along with the new offset and
+// if operations after the offset were dropped
+func (set *LWWSet) OpsSince(offset int) ([]Op, int, bool) {
+	lwwops, offset, truncated := set.Set.OpsSince(offset)
+
+	ops := make([]Op, len(lwwops))
+	for index, op := range lwwops {
+		ops[index] = Op{ID: op.ID, Type: OpType(op.Type), Node: toNode(op.Node)}
+	}
+	return ops, offset, truncated
+}
+
+// ApplyOps applies the operations not applied yet
+// and returns the number of operations applied
+func (set *LWWSet) ApplyOps(ops ...Op) int {
+	lwwops := make([]lwwset.Op[string], len(ops))
+	for index, op := range ops {
+		lwwops[index] = lwwset.Op[string]{ID: op.ID, Type: lwwset.OpType(op.Type), Node: fromNode(op.Node)}
+	}
+
+	var applied int
+	set.Set, applied = set.Set.ApplyOps(lwwops...)
+	return applied
+}
+
+// History returns the Records of the
+// operations observed on the value
+func (set *LWWSet) History(value string) ([]Record, error) {
+	lwwrecords, err := set.Set.History(value)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(lwwrecords))
+	for index, record := range lwwrecords {
+		records[index] = Record{Type: OpType(record.Type), Node: toNode(record.Node)}
+	}
+	return records, nil
+}
+
+// LookupAt returns if the value was present
+// in the LWWSet at the given time
+func (set *LWWSet) LookupAt(value string, t time.Time) (bool, error) {
+	return set.Set.LookupAt(value, t)
+}
+
+// ListAt returns the values present
+// in the LWWSet at the given time
+func (set *LWWSet) ListAt(t time.Time) ([]string, error) {
+	return set.Set.ListAt(t)
+}
+
+// PruneTombstones drops the removed values
+// not changed after the stable version
+func (set *LWWSet) PruneTombstones(version uint64) int {
+	return set.Set.PruneTombstones(version)
+}
+
+// PruneTombstonesBefore drops the removed
+// values removed before the given time
+func (set *LWWSet) PruneTombstonesBefore(t time.Time) int {
+	return set.Set.PruneTombstonesBefore(t)
+}
+
+// toNode converts the Node of a LWWSet into a Node
+func toNode(node lwwset.LWWNode) Node {
+	return Node{
+		Value:     node.Value,
+		Timestamp: node.Timestamp,
+		Replica:   node.Replica,
+		Expiry:    node.Expiry,
+		Metadata:  Metadata(node.Metadata),
+	}
+}
+
+// fromNode converts a Node into the Node of a LWWSet
+func fromNode(node Node) lwwset.LWWNode {
+	return lwwset.LWWNode{
+		Value:     node.Value,
+		Timestamp: node.Timestamp,
+		Replica:   node.Replica,
+		Expiry:    node.Expiry,
+		Metadata:  lwwset.Metadata(node.Metadata),
+	}
+}
@@ -0,0 +1,158 @@
+package crdt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/lwwset"
+	"github.com/el10savio/lwwset-crdt/orset"
+)
+
+// TestLWWSet checks the basic functionality of the Set of a LWWSet
+func TestLWWSet(t *testing.T) {
+	var set Set = NewLWWSet(lwwset.Initialize(lwwset.WithClock(lwwset.NewCounterClock())))
+
+	assert.Nil(t, set.Add("xx"))
+	assert.Nil(t, set.Add("yy"))
+	assert.Nil(t, set.Remove("yy"))
+
+	present, err := set.Lookup("xx")
+
+	assert.Nil(t, err)
+	assert.True(t, present)
+	assert.Equal(t, []string{"xx"}, set.List())
+}
+
+// TestLWWSet_Merge checks that the Set of a LWWSet merges a Set of
+// a LWWSet decoded from JSON into an Empty Set of the same type
+func TestLWWSet_Merge(t *testing.T) {
+	clock := lwwset.NewCounterClock()
+	var set1 Set = NewLWWSet(lwwset.Initialize(lwwset.WithClock(clock)))
+	var set2 Set = NewLWWSet(lwwset.Initialize(lwwset.WithClock(clock)))
+
+	set1.Add("xx")
+	set2.Add("yy")
+	set2.Remove("xx")
+
+	data, err := json.Marshal(set2)
+	assert.Nil(t, err)
+
+	peerSet := set1.Empty()
+	err = json.Unmarshal(data, peerSet)
+	assert.Nil(t, err)
+
+	err = set1.Merge(peerSet)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"yy"}, set1.List())
+}
+
+// TestLWWSet_MergeMismatch checks that the Set of a LWWSet is not
+// merged with the Set of another type or of a LWWSet with another bias
+func TestLWWSet_MergeMismatch(t *testing.T) {
+	set := NewLWWSet(lwwset.Initialize())
+
+	err := set.Merge(set.Empty())
+	assert.Nil(t, err)
+
+	err = set.Merge(NewORSet(orset.Initialize()))
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	err = set.Merge(NewLWWSet(lwwset.Initialize(lwwset.WithBias(lwwset.AddBias))))
+	assert.True(t, errors.Is(err, lwwset.ErrBiasMismatch))
+}
+
+// TestLWWSet_Interfaces checks that the Set of a LWWSet
+// supports delta-state & op-based replication, history,
+// TTLs, metadata, batches and the garbage collection of
+// removed values
+func TestLWWSet_Interfaces(t *testing.T) {
+	var set Set = NewLWWSet(lwwset.Initialize())
+
+	_, isDeltaSet := set.(DeltaSet)
+	_, isOpSet := set.(OpSet)
+	_, isPruneSet := set.(PruneSet)
+	_, isHistorySet := set.(HistorySet)
+	_, isTTLSet := set.(TTLSet)
+	_, isMetadataSet := set.(MetadataSet)
+	_, isBatchSet := set.(BatchSet)
+
+	assert.True(t, isDeltaSet)
+	assert.True(t, isBatchSet)
+	assert.True(t, isMetadataSet)
+	assert.True(t, isTTLSet)
+	assert.True(t, isHistorySet)
+	assert.True(t, isOpSet)
+	assert.True(t, isPruneSet)
+}
+
+// TestLWWSet_DeltaSince checks that the Set of a LWWSet returns
+// a Set of the changes made to the LWWSet since a version
+func TestLWWSet_DeltaSince(t *testing.T) {
+	set := NewLWWSet(lwwset.Initialize(lwwset.WithClock(lwwset.NewCounterClock())))
+
+	set.Add("xx")
+	version := set.Version()
+	set.Add("yy")
+
+	assert.Equal(t, []string{"yy"}, set.DeltaSince(version).List())
+}
+
+// TestLWWSet_ApplyOps checks that the Set of a LWWSet
+// applies the operations of another LWWSet's op log
+func TestLWWSet_ApplyOps(t *testing.T) {
+	set1 := NewLWWSet(lwwset.Initialize(lwwset.WithOpLog(0)))
+	set2 := NewLWWSet(lwwset.Initialize(lwwset.WithOpLog(0)))
+
+	set1.Add("xx")
+	ops, _, _ := set1.OpsSince(0)
+
+	assert.Equal(t, 1, set2.ApplyOps(ops...))
+	assert.Equal(t, []string{"xx"}, set2.List())
+}
+
+// TestLWWSet_OpsJSON checks that the operations of the Set of a
+// LWWSet are encoded as the operations of the LWWSet, so nodes
+// of every version sync each other's op logs, and are applied
+// with their Metadata & expiry
+func TestLWWSet_OpsJSON(t *testing.T) {
+	set1 := NewLWWSet(lwwset.Initialize(lwwset.WithOpLog(0), lwwset.WithReplica("a")))
+	set2 := NewLWWSet(lwwset.Initialize(lwwset.WithOpLog(0)))
+
+	set1.AddWithMetadata("xx", Metadata{"owner": "a"})
+	set1.AddWithTTL("yy", time.Hour)
+	set1.Remove("zz")
+
+	ops, _, _ := set1.OpsSince(0)
+	lwwops, _, _ := set1.Set.OpsSince(0)
+
+	expectedValue, _ := json.Marshal(lwwops)
+	actualValue, err := json.Marshal(ops)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(expectedValue), string(actualValue))
+
+	var decoded []Op
+	assert.Nil(t, json.Unmarshal(actualValue, &decoded))
+	assert.Equal(t, 3, set2.ApplyOps(decoded...))
+	assert.Equal(t, []Element{{Value: "xx", Metadata: Metadata{"owner": "a"}}, {Value: "yy"}}, set2.ListWithMetadata())
+
+	for index, node := range set2.Set.AddNodes() {
+		assert.True(t, set1.Set.AddNodes()[index].Expiry.Equal(node.Expiry))
+	}
+	assert.Equal(t, "a", set2.Set.RemoveNodes()[0].Replica)
+}
+
+// TestSet_String checks that the Sets are printed
+// with their contents rather than their storage
+func TestSet_String(t *testing.T) {
+	for _, set := range []Set{NewLWWSet(lwwset.Initialize()), NewORSet(orset.Initialize())} {
+		set.Add("xx")
+		assert.Contains(t, fmt.Sprint(set), "xx")
+		assert.NotContains(t, fmt.Sprint(set), "0x")
+	}
+}
@@ -0,0 +1,82 @@
+package crdt
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Node is a value of a Set along with the timestamp &
+// replica of the operation made on it, when it expires
+// if added with a TTL and the Metadata it was added with
+// It is encoded in JSON with the timestamps in nanoseconds
+type Node struct {
+	Value     string
+	Timestamp time.Time
+	Replica   string `json:",omitempty"`
+	Expiry    time.Time
+	Metadata  Metadata `json:",omitempty"`
+}
+
+// jsonNode has the fields of a Node without its JSON
+// methods to encode the fields other than the timestamps
+type jsonNode Node
+
+// MarshalJSON encodes the Node with its timestamp & expiry
+// as integers of nanoseconds, the expiry is omitted if not set
+func (node Node) MarshalJSON() ([]byte, error) {
+	var expiry int64
+	if !node.Expiry.IsZero() {
+		expiry = node.Expiry.UnixNano()
+	}
+
+	return json.Marshal(struct {
+		jsonNode
+		Timestamp int64
+		Expiry    int64 `json:",omitempty"`
+	}{
+		jsonNode:  jsonNode(node),
+		Timestamp: node.Timestamp.UnixNano(),
+		Expiry:    expiry,
+	})
+}
+
+// UnmarshalJSON decodes the Node with its timestamp either as an
+// integer of nanoseconds or as a RFC 3339 string sent by older nodes
+func (node *Node) UnmarshalJSON(data []byte) error {
+	decoded := struct {
+		*jsonNode
+		Timestamp json.RawMessage
+		Expiry    int64
+	}{
+		jsonNode: (*jsonNode)(node),
+	}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	node.Expiry = time.Time{}
+	if decoded.Expiry != 0 {
+		node.Expiry = time.Unix(0, decoded.Expiry)
+	}
+
+	if len(decoded.Timestamp) == 0 || bytes.Equal(decoded.Timestamp, []byte("null")) {
+		node.Timestamp = time.Time{}
+		return nil
+	}
+
+	if decoded.Timestamp[0] == '"' {
+		return json.Unmarshal(decoded.Timestamp, &node.Timestamp)
+	}
+
+	var nanoseconds int64
+	err = json.Unmarshal(decoded.Timestamp, &nanoseconds)
+	if err != nil {
+		return err
+	}
+
+	node.Timestamp = time.Unix(0, nanoseconds)
+	return nil
+}
@@ -0,0 +1,80 @@
+package crdt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/el10savio/lwwset-crdt/orset"
+)
+
+// ORSet is the Set implementation of the ORSet
+// It is replicated by merging the entire ORSet
+type ORSet struct {
+	Set orset.ORSet
+}
+
+// NewORSet returns the Set of the given ORSet
+func NewORSet(set orset.ORSet) *ORSet {
+	return &ORSet{Set: set}
+}
+
+// Add adds the value to the ORSet
+func (set *ORSet) Add(value string) error {
+	var err error
+	set.Set, err = set.Set.Addition(value)
+	return err
+}
+
+// Remove removes the value from the ORSet
+func (set *ORSet) Remove(value string) error {
+	var err error
+	set.Set, err = set.Set.Removal(value)
+	return err
+}
+
+// Lookup returns if the value is present in the ORSet
+func (set *ORSet) Lookup(value string) (bool, error) {
+	return set.Set.Lookup(value)
+}
+
+// List returns the values present in the ORSet
+func (set *ORSet) List() []string {
+	_, values := set.Set.List()
+	return values
+}
+
+// String returns the contents of the ORSet
+func (set *ORSet) String() string {
+	return set.Set.String()
+}
+
+// Merge merges the given ORSet into the ORSet
+func (set *ORSet) Merge(other Set) error {
+	otherSet, ok := other.(*ORSet)
+	if !ok {
+		return ErrTypeMismatch
+	}
+
+	set.Set = set.Set.Join(otherSet.Set)
+	return nil
+}
+
+// Empty returns a new empty ORSet
+func (set *ORSet) Empty() Set {
+	return NewORSet(orset.Initialize())
+}
+
+// MarshalJSON encodes the ORSet
+func (set *ORSet) MarshalJSON() ([]byte, error) {
+	return set.Set.MarshalJSON()
+}
+
+// UnmarshalJSON decodes the ORSet, it returns
+// ErrTypeMismatch for another set type's encoding
+func (set *ORSet) UnmarshalJSON(data []byte) error {
+	err := set.Set.UnmarshalJSON(data)
+	if errors.Is(err, orset.ErrTypeMismatch) {
+		return fmt.Errorf("%w: %w", ErrTypeMismatch, err)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package crdt
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/lwwset"
+	"github.com/el10savio/lwwset-crdt/orset"
+)
+
+// TestORSet checks the basic functionality of the Set of an ORSet
+func TestORSet(t *testing.T) {
+	var set Set = NewORSet(orset.Initialize())
+
+	assert.Nil(t, set.Add("xx"))
+	assert.Nil(t, set.Add("yy"))
+	assert.Nil(t, set.Remove("yy"))
+
+	present, err := set.Lookup("xx")
+
+	assert.Nil(t, err)
+	assert.True(t, present)
+	assert.Equal(t, []string{"xx"}, set.List())
+}
+
+// TestORSet_Merge checks that the Set of an ORSet merges a Set of
+// an ORSet decoded from JSON into an Empty Set of the same type
+func TestORSet_Merge(t *testing.T) {
+	var set1 Set = NewORSet(orset.Initialize())
+	var set2 Set = NewORSet(orset.Initialize())
+
+	set1.Add("xx")
+	set2.Add("yy")
+
+	data, err := json.Marshal(set2)
+	assert.Nil(t, err)
+
+	peerSet := set1.Empty()
+	err = json.Unmarshal(data, peerSet)
+	assert.Nil(t, err)
+
+	err = set1.Merge(peerSet)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"xx", "yy"}, set1.List())
+}
+
+// TestORSet_MergeMismatch checks that the Set of an
+// ORSet is not merged with the Set of another type
+func TestORSet_MergeMismatch(t *testing.T) {
+	set := NewORSet(orset.Initialize())
+
+	err := set.Merge(NewLWWSet(lwwset.Initialize()))
+
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+}
+
+// TestDecodeMismatch checks that the Set of each type refuses
+// to decode the Set of the other type with ErrTypeMismatch
+func TestDecodeMismatch(t *testing.T) {
+	lwwSet := NewLWWSet(lwwset.Initialize())
+	lwwSet.Add("xx")
+	orSet := NewORSet(orset.Initialize())
+	orSet.Add("xx")
+
+	lwwData, _ := json.Marshal(lwwSet)
+	orData, _ := json.Marshal(orSet)
+
+	err := json.Unmarshal(orData, lwwSet.Empty())
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	err = json.Unmarshal(lwwData, orSet.Empty())
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+}
+
+// TestORSet_Interfaces checks that the Set of an ORSet
+// is only replicated by merging the entire ORSet
+func TestORSet_Interfaces(t *testing.T) {
+	var set Set = NewORSet(orset.Initialize())
+
+	_, isDeltaSet := set.(DeltaSet)
+	_, isOpSet := set.(OpSet)
+	_, isPruneSet := set.(PruneSet)
+	_, isHistorySet := set.(HistorySet)
+	_, isTTLSet := set.(TTLSet)
+	_, isMetadataSet := set.(MetadataSet)
+	_, isBatchSet := set.(BatchSet)
+
+	assert.False(t, isDeltaSet)
+	assert.False(t, isBatchSet)
+	assert.False(t, isMetadataSet)
+	assert.False(t, isTTLSet)
+	assert.False(t, isHistorySet)
+	assert.False(t, isOpSet)
+	assert.False(t, isPruneSet)
+}
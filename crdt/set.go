@@ -0,0 +1,181 @@
+package crdt
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// package crdt defines the Set interface implemented by the CRDT sets served by a node, so that
+// the handlers are decoupled from the implementation of the set. The functionality specific to
+// some sets, such as delta-state & op-based replication or garbage collection, is defined by
+// optional interfaces. It also provides the Set implementations of the LWWSet & the ORSet
+
+// ErrTypeMismatch is returned when merging
+// Sets of different implementations
+var ErrTypeMismatch = errors.New("set type mismatch")
+
+// Set is a CRDT set of string values which
+// can be merged with a Set of the same type
+// and is encoded in JSON to be sent to peers
+// The methods reading the Set do not change it
+// so they can be called concurrently
+type Set interface {
+	// Add adds the value to the Set
+	Add(value string) error
+	// Remove removes the value from the Set
+	Remove(value string) error
+	// Lookup returns if the value is present in the Set
+	Lookup(value string) (bool, error)
+	// List returns the values present in the Set
+	List() []string
+	// Merge merges the given Set into the Set
+	Merge(other Set) error
+	// Empty returns a new empty Set of the same
+	// type to decode a peer's Set into
+	Empty() Set
+	// String returns the contents of
+	// the Set, as printed in the logs
+	String() string
+
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// DeltaSet is a Set which can be replicated
+// through the changes made since a version
+type DeltaSet interface {
+	Set
+	// Version returns the current version of the Set
+	Version() uint64
+	// DeltaSince returns a Set of the
+	// changes made since the version
+	DeltaSince(version uint64) Set
+}
+
+// OpType is the type of an operation
+// made on a value of a Set
+type OpType string
+
+const (
+	// AddOp is the operation adding a value
+	AddOp OpType = "add"
+
+	// RemoveOp is the operation removing a value
+	RemoveOp OpType = "remove"
+)
+
+// Op is an operation made on a Set
+// identified by its ID
+type Op struct {
+	ID   string `json:"id"`
+	Type OpType `json:"type"`
+	Node Node   `json:"node"`
+}
+
+// OpSet is a Set which can be replicated
+// through the operations made on it
+type OpSet interface {
+	Set
+	// OpsSince returns the operations in the op log
+	// after the offset along with the new offset and
+	// if operations after the offset were dropped
+	OpsSince(offset int) ([]Op, int, bool)
+	// ApplyOps applies the operations not applied yet
+	// and returns the number of operations applied
+	ApplyOps(ops ...Op) int
+}
+
+// Record is an operation made
+// on a value of a Set
+type Record struct {
+	Type OpType `json:"type"`
+	Node Node   `json:"node"`
+}
+
+// HistorySet is a Set which records the operations
+// observed on each value and can be queried at a time
+type HistorySet interface {
+	Set
+	// History returns the Records of the
+	// operations observed on the value
+	History(value string) ([]Record, error)
+	// LookupAt returns if the value was present
+	// in the Set at the given time
+	LookupAt(value string, t time.Time) (bool, error)
+	// ListAt returns the values present
+	// in the Set at the given time
+	ListAt(t time.Time) ([]string, error)
+}
+
+// TTLSet is a Set whose values
+// can expire after a TTL
+type TTLSet interface {
+	Set
+	// AddWithTTL adds the value to the
+	// Set expiring after the TTL
+	AddWithTTL(value string, ttl time.Duration) error
+	// Expire removes the values expired from
+	// the Set and returns the number removed
+	Expire() int
+}
+
+// Metadata holds the attributes of a value
+type Metadata map[string]any
+
+// Element is a value present in a
+// Set along with its Metadata
+type Element struct {
+	Value    string   `json:"value"`
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// MetadataSet is a Set whose values
+// carry replicated Metadata
+type MetadataSet interface {
+	Set
+	// AddWithMetadata adds the value to
+	// the Set along with its Metadata
+	AddWithMetadata(value string, metadata Metadata) error
+	// ListWithMetadata returns the values present
+	// in the Set along with their Metadata
+	ListWithMetadata() []Element
+}
+
+// TTLMetadataSet is a Set whose values can expire
+// after a TTL while carrying replicated Metadata
+type TTLMetadataSet interface {
+	TTLSet
+	MetadataSet
+	// AddWithTTLAndMetadata adds the value to the Set
+	// along with its Metadata expiring after the TTL
+	AddWithTTLAndMetadata(value string, ttl time.Duration, metadata Metadata) error
+}
+
+// BatchOp is an add or a remove of
+// a value applied in a batch to a Set
+type BatchOp struct {
+	Type  OpType `json:"type"`
+	Value string `json:"value"`
+}
+
+// BatchSet is a Set which applies a batch
+// of adds & removes in a single pass
+type BatchSet interface {
+	Set
+	// ApplyBatch applies the operations and
+	// returns the error of each operation
+	ApplyBatch(ops []BatchOp) []error
+}
+
+// PruneSet is a Set whose removed
+// values are garbage collected
+type PruneSet interface {
+	Set
+	// PruneTombstones drops the removed values
+	// not changed after the stable version
+	PruneTombstones(version uint64) int
+	// PruneTombstonesBefore drops the removed
+	// values removed before the given time
+	PruneTombstonesBefore(t time.Time) int
+}
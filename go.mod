@@ -1,6 +1,6 @@
 module github.com/el10savio/lwwset-crdt
 
-go 1.15
+go 1.24
 
 require (
 	github.com/gorilla/mux v1.8.0
@@ -8,4 +8,11 @@ require (
 	github.com/stretchr/testify v1.6.1
 )
 
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20191026070338-33540a1f6037 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
+
 replace github.com/el10savio/lwwset-crdt/handlers => ./handlers
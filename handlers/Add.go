@@ -3,32 +3,95 @@ package handlers
 import (
 	"net/http"
 
-	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
 )
 
+// AddRequest is the JSON struct encapsulating
+// the Add Request body, the value is only
+// used if not given in the URL params
+type AddRequest struct {
+	ValueRequest
+	Metadata crdt.Metadata `json:"metadata"`
+}
+
 // Add is the HTTP handler used to append
-// values to the LWWSet node in the server
+// values to the Set node in the server
 func Add(w http.ResponseWriter, r *http.Request) {
 	var err error
 
+	// Obtain the value & metadata from
+	// the JSON request body if present
+	request := AddRequest{}
+	err = decodeBody(w, r, &request)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode add request")
+		writeError(w, err)
+		return
+	}
+
 	// Obtain the value from URL params
-	value := mux.Vars(r)["value"]
+	// or else from the request body
+	value, err := requestValue(r, request.ValueRequest)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode add request value")
+		writeError(w, err)
+		return
+	}
 
-	// Add the given value to our stored LWWSet
-	LWWSet, err = LWWSet.Addition(value)
+	// Obtain the number of nodes which must acknowledge
+	// the write from the "w" URL query parameter
+	acks, err := requiredAcks(r.URL.Query().Get("w"), len(GetRemotePeerList()))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to parse add write acks")
+		writeError(w, err)
+		return
+	}
+	since := localVersion()
+
+	switch {
+	// Add the given value expiring after the TTL given in
+	// the "ttl" URL query parameter if set, along with
+	// its metadata if provided
+	case r.URL.Query().Get("ttl") != "":
+		err = addWithTTL(value, r.URL.Query().Get("ttl"), request.Metadata)
+
+	// Add the given value along with
+	// its metadata if provided
+	case request.Metadata != nil:
+		err = addWithMetadata(value, request.Metadata)
+
+	// Add the given value to our stored Set
+	default:
+		err = writeLocal(func(set crdt.Set) error {
+			err := set.Add(value)
+			if err != nil {
+				return err
+			}
+
+			// DEBUG log in the case of success indicating
+			// the new Set and the value added
+			log.WithFields(log.Fields{
+				"set":   set,
+				"value": value,
+			}).Debug("successful lwwset addition")
+
+			return nil
+		})
+	}
 	if err != nil {
 		log.WithFields(log.Fields{"error": err}).Error("failed to add value")
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	// DEBUG log in the case of success indicating
-	// the new LWWSet and the value added
-	log.WithFields(log.Fields{
-		"set":   LWWSet,
-		"value": value,
-	}).Debug("successful lwwset addition")
+	// Wait for the other nodes to acknowledge
+	// the write if required
+	if acks > 1 {
+		replicateWrite(w, since, acks, nil)
+		return
+	}
 
 	// Return HTTP 200 OK in the case of success
 	w.WriteHeader(http.StatusOK)
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// parseAt parses the time given in the "at" URL query
+// parameter either in nanoseconds or as a RFC 3339 string
+func parseAt(at string) (time.Time, error) {
+	nanoseconds, err := strconv.ParseInt(at, 10, 64)
+	if err == nil {
+		return time.Unix(0, nanoseconds), nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return parsed, nil
+}
+
+// listAt writes the values present in the Set at the
+// time given in the "at" URL query parameter
+func listAt(w http.ResponseWriter, r *http.Request) {
+	at, err := parseAt(r.URL.Query().Get("at"))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to parse lwwset list time")
+		writeError(w, err)
+		return
+	}
+
+	var values []string
+	err = readHistory(func(set crdt.HistorySet) error {
+		var err error
+		values, err = set.ListAt(at)
+		return err
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to query lwwset history")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success
+	// indicating the values at the time
+	log.WithFields(log.Fields{
+		"at":  at,
+		"set": values,
+	}).Debug("successful lwwset list at")
+
+	// JSON encode response value
+	json.NewEncoder(w).Encode(values)
+}
+
+// lookupAt writes if the value was present in the Set at
+// the time given in the "at" URL query parameter
+func lookupAt(w http.ResponseWriter, r *http.Request, value string) {
+	at, err := parseAt(r.URL.Query().Get("at"))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to parse lwwset lookup time")
+		writeError(w, err)
+		return
+	}
+
+	var present bool
+	err = readHistory(func(set crdt.HistorySet) error {
+		var err error
+		present, err = set.LookupAt(value, at)
+		return err
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to query lwwset history")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success indicating
+	// the lookup value, the time and if its present
+	log.WithFields(log.Fields{
+		"at":      at,
+		"value":   value,
+		"present": present,
+	}).Debug("successful lwwset lookup at")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(IsPresent{present})
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// BatchResult is the JSON struct encapsulating the
+// result of an operation in the Batch Response
+type BatchResult struct {
+	Type    crdt.OpType `json:"type"`
+	Value   string      `json:"value"`
+	Success bool        `json:"success"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Batch is the HTTP handler used to apply a JSON array of add
+// & remove operations to the Set node in the server at once
+// It returns the result of each operation in the same order
+func Batch(w http.ResponseWriter, r *http.Request) {
+	// Obtain the operations from the JSON request body
+	var ops []crdt.BatchOp
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize)).Decode(&ops)
+	if err != nil {
+		err = requestError(err)
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode lwwset batch")
+		writeError(w, err)
+		return
+	}
+
+	// Obtain the number of nodes which must acknowledge
+	// the write from the "w" URL query parameter
+	acks, err := requiredAcks(r.URL.Query().Get("w"), len(GetRemotePeerList()))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to parse lwwset batch write acks")
+		writeError(w, err)
+		return
+	}
+	since := localVersion()
+
+	// Apply the operations to our stored Set in a single
+	// pass, or one at a time if the Set does not support it
+	var errs []error
+	writeLocal(func(set crdt.Set) error {
+		errs = applyBatch(set, ops)
+		return nil
+	})
+
+	results := make([]BatchResult, len(ops))
+	for index, op := range ops {
+		results[index] = BatchResult{Type: op.Type, Value: op.Value, Success: errs[index] == nil}
+		if errs[index] != nil {
+			results[index].Error = errs[index].Error()
+		}
+	}
+
+	// DEBUG log in the case of success
+	// indicating the number of operations
+	log.WithFields(log.Fields{
+		"ops": len(ops),
+	}).Debug("successful lwwset batch")
+
+	// Wait for the other nodes to acknowledge
+	// the write if required
+	if acks > 1 {
+		replicateWrite(w, since, acks, results)
+		return
+	}
+
+	// JSON encode response value
+	json.NewEncoder(w).Encode(results)
+}
+
+// applyBatch applies the operations to the Set and
+// returns the error of each operation
+func applyBatch(set crdt.Set, ops []crdt.BatchOp) []error {
+	if batchSet, ok := set.(crdt.BatchSet); ok {
+		return batchSet.ApplyBatch(ops)
+	}
+
+	errs := make([]error, len(ops))
+	for index, op := range ops {
+		switch op.Type {
+		case crdt.AddOp:
+			errs[index] = set.Add(op.Value)
+		case crdt.RemoveOp:
+			errs[index] = set.Remove(op.Value)
+		default:
+			errs[index] = lwwset.ErrInvalidOpType
+		}
+	}
+	return errs
+}
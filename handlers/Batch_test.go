@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBatch checks that the Batch handler applies the operations
+// of the batch and returns the result of each operation
+func TestBatch(t *testing.T) {
+	for _, setType := range []string{LWWSetType, ORSetType} {
+		replace(t, &LocalReplica, NewReplica(NewSet(setType)))
+		router := Router()
+
+		body := `[{"type":"add","value":"xx"},{"type":"add","value":"yy"},{"type":"remove","value":"xx"},{"type":"add","value":""},{"type":"move","value":"zz"}]`
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/batch", strings.NewReader(body)))
+
+		var results []BatchResult
+		err := json.NewDecoder(response.Body).Decode(&results)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, []BatchResult{
+			{Type: "add", Value: "xx", Success: true},
+			{Type: "add", Value: "yy", Success: true},
+			{Type: "remove", Value: "xx", Success: true},
+			{Type: "add", Value: "", Success: false, Error: "empty value provided"},
+			{Type: "move", Value: "zz", Success: false, Error: "invalid operation type provided"},
+		}, results, setType)
+
+		response = httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list", nil))
+
+		var values []string
+		json.NewDecoder(response.Body).Decode(&values)
+		assert.Contains(t, values, "yy", setType)
+	}
+}
+
+// TestBatch_InvalidBody checks that the Batch handler
+// refuses a body which is not a JSON array of operations
+func TestBatch_InvalidBody(t *testing.T) {
+	response := httptest.NewRecorder()
+	Router().ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/batch", strings.NewReader(`{"type":"add"}`)))
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+var (
+	// Epoch identifies this run of the node, versions
+	// of the Set are only comparable within an Epoch
+	Epoch string
+)
+
+func init() {
+	epoch := make([]byte, 8)
+	rand.Read(epoch)
+	Epoch = hex.EncodeToString(epoch)
+}
+
+// DeltaResponse is the JSON struct
+// encapsulating the Delta Response
+type DeltaResponse struct {
+	Epoch   string   `json:"epoch"`
+	Version uint64   `json:"version"`
+	Delta   crdt.Set `json:"delta"`
+}
+
+// Delta is the HTTP handler to return the changes made to the local
+// Set since the version given in the "since" URL query parameter
+// without syncing it with other nodes in a cluster. The requesting
+// peer passes its ID & our epoch in the "peer" & "epoch" parameters
+// to acknowledge it has merged the local Set up to that version
+func Delta(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var since uint64
+
+	// Obtain the version from the URL query,
+	// the entire Set is returned if not set
+	if r.URL.Query().Get("since") != "" {
+		since, err = strconv.ParseUint(r.URL.Query().Get("since"), 10, 64)
+		if err != nil {
+			log.WithFields(log.Fields{"error": err}).Error("failed to parse lwwset delta version")
+			writeError(w, fmt.Errorf("%w: %v", ErrInvalidRequest, err))
+			return
+		}
+	}
+
+	// The version is read along with the delta so
+	// that it does not miss changes made in between
+	var version uint64
+	var JSONResponse []byte
+	err = LocalReplica.Read(func(set crdt.Set) error {
+		deltaSet, ok := set.(crdt.DeltaSet)
+		if !ok {
+			return ErrUnsupported
+		}
+
+		// A version ahead of ours was obtained
+		// before a restart so send everything
+		version = deltaSet.Version()
+		if since > version {
+			since = 0
+		}
+
+		var err error
+		JSONResponse, err = json.Marshal(DeltaResponse{
+			Epoch:   Epoch,
+			Version: version,
+			Delta:   deltaSet.DeltaSince(since),
+		})
+		return err
+	})
+
+	// Return HTTP 501 if the Set served
+	// cannot be replicated by deltas
+	// or HTTP 500 if not encoded
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to obtain lwwset delta")
+		writeError(w, err)
+		return
+	}
+
+	// The version is only acknowledged if
+	// it was obtained in the current epoch
+	peer := r.URL.Query().Get("peer")
+	if peer != "" && r.URL.Query().Get("epoch") == Epoch {
+		Acknowledge(peer, since)
+	}
+
+	// DEBUG log in the case of success
+	// indicating the delta and its version
+	log.WithFields(log.Fields{
+		"since":   since,
+		"version": version,
+		"delta":   string(JSONResponse),
+	}).Debug("successful lwwset delta")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(JSONResponse)
+}
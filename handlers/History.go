@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// History is the HTTP handler used to return the operations
+// observed on a given value of the Set node in the server
+func History(w http.ResponseWriter, r *http.Request) {
+	// Obtain the value from URL params
+	value := mux.Vars(r)["value"]
+
+	// Sync the Sets before reading for
+	// the consistency requested
+	if readSet(w, r) != nil {
+		return
+	}
+
+	// Get the history of the given value in the Set
+	var records []crdt.Record
+	err := readHistory(func(set crdt.HistorySet) error {
+		var err error
+		records, err = set.History(value)
+		return err
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to query lwwset history")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success indicating
+	// the value and the number of records
+	log.WithFields(log.Fields{
+		"value":   value,
+		"records": len(records),
+	}).Debug("successful lwwset history")
+
+	// JSON encode response value
+	json.NewEncoder(w).Encode(records)
+}
+
+// readHistory calls read with the Set served if it
+// records its history or returns ErrUnsupported
+func readHistory(read func(set crdt.HistorySet) error) error {
+	return LocalReplica.Read(func(set crdt.Set) error {
+		historySet, ok := set.(crdt.HistorySet)
+		if !ok {
+			return ErrUnsupported
+		}
+		return read(historySet)
+	})
+}
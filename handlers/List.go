@@ -5,24 +5,51 @@ import (
 	"net/http"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
 )
 
 // List is the HTTP handler used to return
-// all the values present in the LWWSet node in the server
+// all the values present in the Set node in the server
 func List(w http.ResponseWriter, r *http.Request) {
-	// Sync the LWWSets if multiple nodes
-	// are present in a cluster
-	if len(GetPeerList()) != 0 {
-		LWWSet, _ = Sync(LWWSet)
+	// Sync the Sets before reading for
+	// the consistency requested
+	if readSet(w, r) != nil {
+		return
+	}
+
+	// Return the values present along with their metadata
+	// if the "metadata" URL query parameter is true
+	if r.URL.Query().Get("metadata") == "true" {
+		listWithMetadata(w)
+		return
 	}
 
-	set := make([]string, 0)
+	// Return the values present at the time given
+	// in the "at" URL query parameter if set
+	if r.URL.Query().Get("at") != "" {
+		listAt(w, r)
+		return
+	}
+
+	// Get the values from the Set
+	var set []string
+	LocalReplica.Read(func(local crdt.Set) error {
+		set = local.List()
+		return nil
+	})
 
-	// Get the values from the LWWSet
-	LWWSet, set = LWWSet.List()
+	// Encode the values in the encoding given in
+	// the "encoding" URL query parameter if set
+	set, err := encodeValues(set, r.URL.Query().Get("encoding"))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to encode lwwset values")
+		writeError(w, err)
+		return
+	}
 
 	// DEBUG log in the case of success
-	// indicating the new LWWSet
+	// indicating the new Set
 	log.WithFields(log.Fields{
 		"set": set,
 	}).Debug("successful lwwset list")
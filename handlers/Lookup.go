@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
 )
 
 // IsPresent is the JSON struct
@@ -15,46 +16,76 @@ type IsPresent struct {
 }
 
 // Lookup is the HTTP handler used to return
-// if a given value is present in the LWWSet node in the server
+// if a given value is present in the Set node in the server
 func Lookup(w http.ResponseWriter, r *http.Request) {
 	var err error
 	var present bool
 
 	// Obtain the value from URL params
-	value := mux.Vars(r)["value"]
+	// or else from the JSON request body
+	request := ValueRequest{}
+	err = decodeBody(w, r, &request)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode lookup request")
+		writeError(w, err)
+		return
+	}
 
-	// Sync the LWWSets if multiple nodes
-	// are present in a cluster
-	if len(GetPeerList()) != 0 {
-		LWWSet, _ = Sync(LWWSet)
+	value, err := requestValue(r, request)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode lookup request value")
+		writeError(w, err)
+		return
 	}
 
-	// Lookup given value in the LWWSet
-	present, err = LWWSet.Lookup(value)
+	// Sync the Sets before reading for
+	// the consistency requested
+	err = readSet(w, r)
 	if err != nil {
-		log.WithFields(log.Fields{"error": err}).Error("failed to lookup lwwset value")
-		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	// DEBUG log in the case of success indicating
-	// the new LWWSet, the lookup value and if its present
-	log.WithFields(log.Fields{
-		"set":     LWWSet,
-		"value":   value,
-		"present": present,
-	}).Debug("successful lwwset lookup")
+	// Return if the value was present at the time
+	// given in the "at" URL query parameter if set
+	if r.URL.Query().Get("at") != "" {
+		lookupAt(w, r, value)
+		return
+	}
+
+	// Lookup given value in the Set
+	err = LocalReplica.Read(func(set crdt.Set) error {
+		var err error
+		present, err = set.Lookup(value)
+		if err != nil {
+			return err
+		}
+
+		// DEBUG log in the case of success indicating
+		// the Set, the lookup value and if its present
+		log.WithFields(log.Fields{
+			"set":     set,
+			"value":   value,
+			"present": present,
+		}).Debug("successful lwwset lookup")
+
+		return nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to lookup lwwset value")
+		writeError(w, err)
+		return
+	}
 
 	isPresent := IsPresent{present}
 
 	JSONResponse, err := json.Marshal(isPresent)
 	if err != nil {
 		log.WithFields(log.Fields{"error": err}).Error("failed to json marshall lookup lwwset value")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Write(JSONResponse)
 	w.WriteHeader(http.StatusOK)
+	w.Write(JSONResponse)
 }
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// MapDelete is the HTTP handler used to delete
+// a key from the LWWMap node in the server
+func MapDelete(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	// Obtain the key from URL params
+	key := mux.Vars(r)["key"]
+
+	// Delete the given key from our stored LWWMap
+	LWWMapMutex.Lock()
+	LWWMap, err = LWWMap.Delete(key)
+	LWWMapMutex.Unlock()
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to delete lwwmap key")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success
+	// indicating the key deleted
+	log.WithFields(log.Fields{
+		"key": key,
+	}).Debug("successful lwwmap delete")
+
+	// Return HTTP 200 OK in the case of success
+	w.WriteHeader(http.StatusOK)
+}
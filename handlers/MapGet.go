@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// MapValue is the JSON struct
+// encapsulating the MapGet Response
+type MapValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Present bool   `json:"present"`
+}
+
+// MapGet is the HTTP handler used to return the value
+// of a given key in the LWWMap node in the server
+func MapGet(w http.ResponseWriter, r *http.Request) {
+	// Obtain the key from URL params
+	key := mux.Vars(r)["key"]
+
+	// Sync the LWWMaps before reading
+	// for the consistency requested
+	if readMap(w, r) != nil {
+		return
+	}
+
+	// Get the given key's value in the LWWMap
+	LWWMapMutex.RLock()
+	value, present, err := LWWMap.Get(key)
+	LWWMapMutex.RUnlock()
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to get lwwmap key")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success indicating
+	// the key, its value and if its present
+	log.WithFields(log.Fields{
+		"key":     key,
+		"value":   value,
+		"present": present,
+	}).Debug("successful lwwmap get")
+
+	JSONResponse, err := json.Marshal(MapValue{Key: key, Value: value, Present: present})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to json marshall lwwmap value")
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(JSONResponse)
+}
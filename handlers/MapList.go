@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MapList is the HTTP handler used to return all the keys
+// with their values present in the LWWMap node in the server
+func MapList(w http.ResponseWriter, r *http.Request) {
+	// Sync the LWWMaps before reading
+	// for the consistency requested
+	if readMap(w, r) != nil {
+		return
+	}
+
+	// Get the keys & values from the LWWMap
+	LWWMapMutex.RLock()
+	_, values := LWWMap.List()
+	LWWMapMutex.RUnlock()
+
+	// DEBUG log in the case of success
+	// indicating the keys & values
+	log.WithFields(log.Fields{
+		"map": values,
+	}).Debug("successful lwwmap list")
+
+	// JSON encode response value
+	json.NewEncoder(w).Encode(values)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// MapPut is the HTTP handler used to set the value of a key
+// in the LWWMap node in the server to the request body
+func MapPut(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	// Obtain the key from URL params
+	key := mux.Vars(r)["key"]
+
+	// Obtain the value from the request body
+	value, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		err = requestError(err)
+		log.WithFields(log.Fields{"error": err}).Error("failed to read lwwmap value")
+		writeError(w, err)
+		return
+	}
+
+	// Put the given key & value in our stored LWWMap
+	LWWMapMutex.Lock()
+	LWWMap, err = LWWMap.Put(key, string(value))
+	LWWMapMutex.Unlock()
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to put lwwmap key")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success
+	// indicating the key & value put
+	log.WithFields(log.Fields{
+		"key":   key,
+		"value": string(value),
+	}).Debug("successful lwwmap put")
+
+	// Return HTTP 200 OK in the case of success
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MapValues is the HTTP handler to return the local LWWMap's
+// values without syncing it with other nodes in a cluster
+func MapValues(w http.ResponseWriter, r *http.Request) {
+	// Get the local LWWMap values
+	LWWMapMutex.RLock()
+	JSONResponse, err := json.Marshal(LWWMap)
+	LWWMapMutex.RUnlock()
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to json marshall lwwmap values")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of successful
+	// values indicating the map
+	log.WithFields(log.Fields{
+		"map": string(JSONResponse),
+	}).Debug("successful lwwmap values")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(JSONResponse)
+}
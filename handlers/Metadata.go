@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// addWithMetadata adds the value to the
+// Set along with the given metadata
+func addWithMetadata(value string, metadata crdt.Metadata) error {
+	return writeLocal(func(set crdt.Set) error {
+		metadataSet, ok := set.(crdt.MetadataSet)
+		if !ok {
+			return ErrUnsupported
+		}
+
+		err := metadataSet.AddWithMetadata(value, metadata)
+		if err != nil {
+			return err
+		}
+
+		// DEBUG log in the case of success indicating
+		// the new Set, the value added and its metadata
+		log.WithFields(log.Fields{
+			"set":      set,
+			"value":    value,
+			"metadata": metadata,
+		}).Debug("successful lwwset addition with metadata")
+
+		return nil
+	})
+}
+
+// listWithMetadata writes the values present
+// in the Set along with their metadata
+func listWithMetadata(w http.ResponseWriter) {
+	var elements []crdt.Element
+	err := LocalReplica.Read(func(set crdt.Set) error {
+		metadataSet, ok := set.(crdt.MetadataSet)
+		if !ok {
+			return ErrUnsupported
+		}
+
+		elements = metadataSet.ListWithMetadata()
+		return nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to list metadata of set without metadata")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success
+	// indicating the elements listed
+	log.WithFields(log.Fields{
+		"set": elements,
+	}).Debug("successful lwwset list with metadata")
+
+	// JSON encode response value
+	json.NewEncoder(w).Encode(elements)
+}
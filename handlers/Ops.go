@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// OpsResponse is the JSON struct
+// encapsulating the Ops Response
+type OpsResponse struct {
+	Epoch     string    `json:"epoch"`
+	Offset    int       `json:"offset"`
+	Ops       []crdt.Op `json:"ops"`
+	Truncated bool      `json:"truncated,omitempty"`
+}
+
+// Ops is the HTTP handler to return the operations in the local
+// Set's op log after the offset given in the "since" URL query
+// parameter. The offset is only used if the "epoch" URL query
+// parameter is the current epoch, otherwise the entire op log is sent
+// The Response is truncated if operations after the offset were
+// dropped from the op log or the op log is not enabled
+func Ops(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var since int
+
+	// Obtain the offset from the URL query,
+	// the entire op log is returned if not set
+	if r.URL.Query().Get("since") != "" && r.URL.Query().Get("epoch") == Epoch {
+		since, err = strconv.Atoi(r.URL.Query().Get("since"))
+		if err != nil {
+			log.WithFields(log.Fields{"error": err}).Error("failed to parse lwwset ops offset")
+			writeError(w, fmt.Errorf("%w: %v", ErrInvalidRequest, err))
+			return
+		}
+	}
+
+	var ops []crdt.Op
+	var offset int
+	var truncated bool
+	err = LocalReplica.Read(func(set crdt.Set) error {
+		opSet, ok := set.(crdt.OpSet)
+		if !ok {
+			return ErrUnsupported
+		}
+
+		ops, offset, truncated = opSet.OpsSince(since)
+		return nil
+	})
+
+	// Return HTTP 501 if the Set served
+	// does not record its operations
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to obtain ops of set without op log")
+		writeError(w, err)
+		return
+	}
+
+	// DEBUG log in the case of success
+	// indicating the offsets of the ops
+	log.WithFields(log.Fields{
+		"since":     since,
+		"offset":    offset,
+		"truncated": truncated,
+	}).Debug("successful lwwset ops")
+
+	// json encode response value
+	json.NewEncoder(w).Encode(OpsResponse{
+		Epoch:     Epoch,
+		Offset:    offset,
+		Ops:       ops,
+		Truncated: truncated,
+	})
+}
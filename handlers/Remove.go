@@ -3,32 +3,71 @@ package handlers
 import (
 	"net/http"
 
-	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
 )
 
 // Remove is the HTTP handler used to remove
-// values to the LWWSet node in the server
+// values to the Set node in the server
 func Remove(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	// Obtain the value from URL params
-	value := mux.Vars(r)["value"]
+	// or else from the JSON request body
+	request := ValueRequest{}
+	err = decodeBody(w, r, &request)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode remove request")
+		writeError(w, err)
+		return
+	}
+
+	value, err := requestValue(r, request)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode remove request value")
+		writeError(w, err)
+		return
+	}
 
-	// Remove the given value to our stored LWWSet
-	LWWSet, err = LWWSet.Removal(value)
+	// Obtain the number of nodes which must acknowledge
+	// the write from the "w" URL query parameter
+	acks, err := requiredAcks(r.URL.Query().Get("w"), len(GetRemotePeerList()))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to parse remove write acks")
+		writeError(w, err)
+		return
+	}
+	since := localVersion()
+
+	// Remove the given value to our stored Set
+	err = writeLocal(func(set crdt.Set) error {
+		err := set.Remove(value)
+		if err != nil {
+			return err
+		}
+
+		// DEBUG log in the case of success indicating
+		// the new Set and the value removed
+		log.WithFields(log.Fields{
+			"set":   set,
+			"value": value,
+		}).Debug("successful lwwset removal")
+
+		return nil
+	})
 	if err != nil {
 		log.WithFields(log.Fields{"error": err}).Error("failed to remove value")
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	// DEBUG log in the case of success indicating
-	// the new LWWSet and the value removed
-	log.WithFields(log.Fields{
-		"set":   LWWSet,
-		"value": value,
-	}).Debug("successful lwwset removal")
+	// Wait for the other nodes to acknowledge
+	// the write if required
+	if acks > 1 {
+		replicateWrite(w, since, acks, nil)
+		return
+	}
 
 	// Return HTTP 200 OK in the case of success
 	w.WriteHeader(http.StatusOK)
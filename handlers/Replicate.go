@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// Replicate is the HTTP handler used to merge the changes
+// pushed by a peer, as a JSON encoded Set in the request
+// body, with the Set node in the server. The changes are
+// refused if the peer's Set is not compatible
+func Replicate(w http.ResponseWriter, r *http.Request) {
+	// Obtain the changes from the JSON request body
+	changes := LocalReplica.Empty()
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxReplicateBodySize)).Decode(changes)
+	if err != nil {
+		err = requestError(err)
+		log.WithFields(log.Fields{"error": err}).Error("failed to decode lwwset changes")
+		writeError(w, err)
+		return
+	}
+
+	// Merge the changes with our stored Set
+	err = LocalReplica.Write(func(set crdt.Set) error {
+		err := set.Merge(changes)
+		if err != nil {
+			return err
+		}
+
+		// DEBUG log in the case of success indicating
+		// the new Set and the changes merged
+		log.WithFields(log.Fields{
+			"set":     set,
+			"changes": changes,
+		}).Debug("successful lwwset replicate")
+
+		return nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to merge lwwset changes")
+		writeError(w, err)
+		return
+	}
+
+	// Return HTTP 200 OK in the case of success
+	w.WriteHeader(http.StatusOK)
+}
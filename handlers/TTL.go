@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// addWithTTL adds the value to the Set expiring after
+// the given TTL duration, along with the given metadata
+// if provided
+func addWithTTL(value string, ttl string, metadata crdt.Metadata) error {
+	duration, err := time.ParseDuration(ttl)
+	if err != nil || duration <= 0 {
+		return fmt.Errorf("%w: %s", lwwset.ErrInvalidTTL, ttl)
+	}
+
+	return writeLocal(func(set crdt.Set) error {
+		var err error
+		if metadata != nil {
+			ttlMetadataSet, ok := set.(crdt.TTLMetadataSet)
+			if !ok {
+				return ErrUnsupported
+			}
+			err = ttlMetadataSet.AddWithTTLAndMetadata(value, duration, metadata)
+		} else {
+			ttlSet, ok := set.(crdt.TTLSet)
+			if !ok {
+				return ErrUnsupported
+			}
+			err = ttlSet.AddWithTTL(value, duration)
+		}
+		if err != nil {
+			return err
+		}
+
+		// DEBUG log in the case of success indicating the new
+		// Set, the value added, its TTL and its metadata
+		log.WithFields(log.Fields{
+			"set":      set,
+			"value":    value,
+			"ttl":      duration,
+			"metadata": metadata,
+		}).Debug("successful lwwset addition with ttl")
+
+		return nil
+	})
+}
+
+// Sweep periodically removes the values expired from the
+// Set, if it supports expiring values, so that the expired
+// values are replicated as removed values to the peers
+func Sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if sweep() != nil {
+			return
+		}
+	}
+}
+
+// sweep removes the values expired from the Set and pushes
+// their removal to the peers, it returns ErrUnsupported if
+// the Set does not support expiring values
+func sweep() error {
+	return writeLocal(func(set crdt.Set) error {
+		ttlSet, ok := set.(crdt.TTLSet)
+		if !ok {
+			return ErrUnsupported
+		}
+
+		// DEBUG log indicating the number
+		// of values expired if any
+		if expired := ttlSet.Expire(); expired != 0 {
+			log.WithFields(log.Fields{
+				"expired": expired,
+			}).Debug("successful lwwset sweep")
+		}
+		return nil
+	})
+}
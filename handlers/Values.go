@@ -5,20 +5,33 @@ import (
 	"net/http"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
 )
 
-// Values is the HTTP handler to return the local LWWSet's values
+// Values is the HTTP handler to return the local Set's values
 // without syncing it with other nodes in a cluster
 func Values(w http.ResponseWriter, r *http.Request) {
-	// Get the local LWWSet values
-	set := LWWSet
+	var JSONResponse []byte
+
+	// Get the local Set values
+	err := LocalReplica.Read(func(set crdt.Set) error {
+		var err error
+		JSONResponse, err = json.Marshal(set)
+		return err
+	})
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to json marshall lwwset values")
+		writeError(w, err)
+		return
+	}
 
 	// DEBUG log in the case of successful
 	// list indicating the set
 	log.WithFields(log.Fields{
-		"set": set,
+		"set": string(JSONResponse),
 	}).Debug("successful lwwset values")
 
-	// json encode response value
-	json.NewEncoder(w).Encode(set)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(JSONResponse)
 }
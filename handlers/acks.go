@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+const (
+	// AckedStatus is the status of a peer
+	// which acknowledged a write
+	AckedStatus = "acked"
+
+	// FailedStatus is the status of a peer
+	// which failed to acknowledge a write
+	FailedStatus = "failed"
+
+	// PendingStatus is the status of a peer which had not
+	// acknowledged a write when the Response was written,
+	// the write is still pushed to the peer
+	PendingStatus = "pending"
+)
+
+// ErrInsufficientAcks is returned when fewer nodes than required
+// acknowledged a write before the timeout. The write is applied
+// on the local node and reaches the other nodes eventually
+var ErrInsufficientAcks = errors.New("insufficient write acks")
+
+// PeerAck is the JSON struct encapsulating the
+// status of a peer in the Write Response
+type PeerAck struct {
+	Peer   string `json:"peer"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// WriteResponse is the JSON struct encapsulating the Response of
+// a write requiring acks from the peers, with the number of nodes
+// including the local node which acknowledged it, the status of
+// each peer and the results of a batch. It carries the fields of
+// the ErrorResponse if fewer nodes than required acknowledged it
+type WriteResponse struct {
+	*ErrorResponse
+	Acks     int           `json:"acks"`
+	Required int           `json:"required"`
+	Peers    []PeerAck     `json:"peers"`
+	Results  []BatchResult `json:"results,omitempty"`
+}
+
+// requiredAcks returns the number of nodes, including the local node,
+// out of the local node & the given number of peers which must
+// acknowledge a write given the "w" URL query parameter. It is
+// either a number of nodes, "quorum" or "all" and defaults to 1
+func requiredAcks(w string, peers int) (int, error) {
+	switch w {
+	case "":
+		return 1, nil
+	case QuorumConsistency:
+		return (peers+1)/2 + 1, nil
+	case AllConsistency:
+		return peers + 1, nil
+	}
+
+	acks, err := strconv.Atoi(w)
+	if err != nil || acks < 1 || acks > peers+1 {
+		return 0, fmt.Errorf("%w: invalid write acks %s for %d nodes", ErrInvalidRequest, w, peers+1)
+	}
+	return acks, nil
+}
+
+// forwardWrite pushes the changes to every peer and waits until the
+// required number of nodes, including the local node, acknowledged
+// them or the timeout elapsed. It returns the WriteResponse of the write
+func forwardWrite(changes crdt.Set, required int, timeout time.Duration) WriteResponse {
+	peers := GetRemotePeerList()
+
+	response := WriteResponse{Acks: 1, Required: required, Peers: make([]PeerAck, len(peers))}
+	if required <= 1 {
+		return response
+	}
+
+	// The changes are pushed to the peers
+	// concurrently, results are buffered so
+	// that late peers do not block
+	type result struct {
+		index int
+		err   error
+	}
+	results := make(chan result, len(peers))
+	for index, peer := range peers {
+		response.Peers[index] = PeerAck{Peer: peer, Status: PendingStatus}
+		go func() {
+			results <- result{index: index, err: SendReplicateRequest(peer, changes)}
+		}()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for received := 0; received < len(peers) && response.Acks < required; received++ {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				response.Peers[result.index].Status = FailedStatus
+				response.Peers[result.index].Error = result.err.Error()
+				continue
+			}
+			response.Peers[result.index].Status = AckedStatus
+			response.Acks++
+		case <-timer.C:
+			return response
+		}
+	}
+
+	return response
+}
+
+// replicateWrite forwards the changes made to the Set of the LocalReplica
+// since the version to the peers and writes the WriteResponse once the
+// required number of nodes acknowledged the write, or with HTTP 503
+// if fewer nodes acknowledged it before the timeout
+func replicateWrite(w http.ResponseWriter, since uint64, required int, results []BatchResult) {
+	response := forwardWrite(localChanges(since), required, GetWriteTimeout())
+	response.Results = results
+
+	status := http.StatusOK
+	if response.Acks < required {
+		err := fmt.Errorf("%w: %d of %d required nodes acknowledged the write, applied on the local node", ErrInsufficientAcks, response.Acks, required)
+		log.WithFields(log.Fields{"error": err, "peers": response.Peers}).Error("failed to replicate write")
+
+		var code string
+		status, code = codeOf(err)
+		response.ErrorResponse = &ErrorResponse{
+			Code:      code,
+			Message:   err.Error(),
+			RequestID: w.Header().Get(RequestIDHeader),
+		}
+	} else {
+		// DEBUG log in the case of success indicating
+		// the nodes which acknowledged the write
+		log.WithFields(log.Fields{
+			"acks":     response.Acks,
+			"required": required,
+		}).Debug("successful write replication")
+	}
+
+	JSONResponse, err := json.Marshal(response)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to json marshall write response")
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(JSONResponse)
+}
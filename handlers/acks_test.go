@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// TestRequiredAcks checks the number of nodes required to
+// acknowledge a write given the "w" URL query parameter
+func TestRequiredAcks(t *testing.T) {
+	tests := []struct {
+		w     string
+		peers int
+		acks  int
+	}{
+		{"", 2, 1},
+		{"1", 2, 1},
+		{"3", 2, 3},
+		{"quorum", 0, 1},
+		{"quorum", 2, 2},
+		{"quorum", 3, 3},
+		{"all", 2, 3},
+	}
+
+	for _, test := range tests {
+		acks, err := requiredAcks(test.w, test.peers)
+		assert.Nil(t, err, test.w)
+		assert.Equal(t, test.acks, acks, test.w)
+	}
+
+	for _, w := range []string{"0", "4", "one"} {
+		_, err := requiredAcks(w, 2)
+		assert.True(t, errors.Is(err, ErrInvalidRequest), w)
+	}
+}
+
+// TestWriteAcks checks that writes requiring acks respond once enough
+// nodes acknowledged them, and report the nodes which acknowledged
+// them with HTTP 503 if fewer nodes than required did
+func TestWriteAcks(t *testing.T) {
+	t.Setenv("WRITE_TIMEOUT", "200ms")
+	router := Router()
+
+	// The peers named "down" fail, the peers named
+	// "slow" respond after the timeout and the others
+	// acknowledge the changes pushed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.Host, "down"):
+			w.WriteHeader(http.StatusInternalServerError)
+		case strings.HasPrefix(r.Host, "slow"):
+			time.Sleep(time.Second)
+		}
+	}))
+	defer server.Close()
+	routePeers(t, server)
+
+	tests := []struct {
+		peers    string
+		url      string
+		body     string
+		status   int
+		acks     int
+		statuses []string
+	}{
+		{"up-1,up-2", "/lwwset/add/xx?w=all", "", http.StatusOK, 3, []string{AckedStatus, AckedStatus}},
+		{"up-1,down-1", "/lwwset/add/xx?w=2", "", http.StatusOK, 2, nil},
+		{"down-1,up-1", "/lwwset/remove/xx?w=quorum", "", http.StatusOK, 2, nil},
+		{"up-1,down-1", "/lwwset/add/xx?w=all", "", http.StatusServiceUnavailable, 2, []string{AckedStatus, FailedStatus}},
+		{"up-1,slow-1", "/lwwset/add/xx?w=all", "", http.StatusServiceUnavailable, 2, []string{AckedStatus, PendingStatus}},
+		{"up-1,up-2", "/lwwset/batch?w=all", `[{"type":"add","value":"xx"}]`, http.StatusOK, 3, []string{AckedStatus, AckedStatus}},
+	}
+
+	for _, test := range tests {
+		t.Setenv("PEERS", test.peers)
+		replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+		request := httptest.NewRequest("POST", test.url, strings.NewReader(test.body))
+		request.Header.Set(RequestIDHeader, "request-1")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		var writeResponse WriteResponse
+		err := json.NewDecoder(response.Body).Decode(&writeResponse)
+
+		name := test.peers + " " + test.url
+		assert.Nil(t, err, name)
+		assert.Equal(t, test.status, response.Code, name)
+		assert.Equal(t, test.acks, writeResponse.Acks, name)
+
+		// The status of the failing peers is not checked if the
+		// write was acknowledged before they may have responded
+		if test.statuses != nil {
+			statuses := []string{}
+			for _, peer := range writeResponse.Peers {
+				statuses = append(statuses, peer.Status)
+			}
+			assert.Equal(t, test.statuses, statuses, name)
+		}
+
+		// A write short of acks is still applied
+		// locally and reported as an error
+		if test.status != http.StatusOK {
+			assert.Equal(t, "insufficient_acks", writeResponse.Code, name)
+			assert.Equal(t, "request-1", writeResponse.RequestID, name)
+
+			LocalReplica.Read(func(set crdt.Set) error {
+				present, _ := set.Lookup("xx")
+				assert.True(t, present, name)
+				return nil
+			})
+		}
+	}
+
+	// The results of a batch are returned with the acks
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/batch?w=2", strings.NewReader(`[{"type":"add","value":""}]`)))
+
+	var writeResponse WriteResponse
+	json.NewDecoder(response.Body).Decode(&writeResponse)
+	assert.Equal(t, []BatchResult{{Type: "add", Value: "", Success: false, Error: "empty value provided"}}, writeResponse.Results)
+}
+
+// TestWriteAcks_Invalid checks that a write requiring more nodes
+// than present in the cluster is refused without being applied
+func TestWriteAcks_Invalid(t *testing.T) {
+	t.Setenv("PEERS", "peer-1")
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/add/xx?w=3", nil))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		present, _ := set.Lookup("xx")
+		assert.False(t, present)
+		return nil
+	})
+}
+
+// TestWriteAcks_Self checks that the local node listed in
+// PEERS is neither pushed to nor counted as a peer
+func TestWriteAcks_Self(t *testing.T) {
+	router := Router()
+
+	// The local node fails if the
+	// write is pushed to it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Host, "self") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	routePeers(t, server)
+	t.Setenv("REPLICA", "self")
+	t.Setenv("PEERS", "self,up-1,up-2")
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/add/xx?w=all", nil))
+
+	var writeResponse WriteResponse
+	json.NewDecoder(response.Body).Decode(&writeResponse)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, 3, writeResponse.Acks)
+	assert.Equal(t, 3, writeResponse.Required)
+	assert.Len(t, writeResponse.Peers, 2)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/add/xx?w=4", nil))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// LocalConsistency serves reads from the local node
+	// without syncing with the peers, it is the default
+	LocalConsistency = "local"
+
+	// OneConsistency syncs with the peers before
+	// a read and requires one peer to respond
+	OneConsistency = "one"
+
+	// QuorumConsistency syncs with the peers before a read
+	// and requires a majority of the nodes in the cluster,
+	// including the local node, to contribute
+	QuorumConsistency = "quorum"
+
+	// AllConsistency syncs with the peers before
+	// a read and requires every peer to respond
+	AllConsistency = "all"
+)
+
+// ReplicasHeader is the HTTP header of a read response carrying
+// the number of nodes, including the local node, contributing
+const ReplicasHeader = "X-Replicas-Contributed"
+
+// requiredPeers returns the number of peers out of the given number
+// of peers that must be synced with before a read of the consistency
+func requiredPeers(consistency string, peers int) (int, error) {
+	switch consistency {
+	case "", LocalConsistency:
+		return 0, nil
+	case OneConsistency:
+		return min(1, peers), nil
+	case QuorumConsistency:
+		return (peers + 1) / 2, nil
+	case AllConsistency:
+		return peers, nil
+	default:
+		return 0, fmt.Errorf("%w: invalid consistency %s", ErrInvalidRequest, consistency)
+	}
+}
+
+// readConsistency syncs with the peers through syncPeers before a read
+// of the consistency given in the "consistency" URL query parameter,
+// returning once the required number of peers were synced, and sets
+// the ReplicasHeader of the response. The local node is never counted
+// as a peer even when listed in PEERS. It writes the error response
+// and returns the error if the consistency is invalid, a peer is not
+// compatible or fewer peers than required could be synced
+func readConsistency(w http.ResponseWriter, r *http.Request, syncPeers func(peers []string, required int) (int, error)) error {
+	peers := GetRemotePeerList()
+
+	required, err := requiredPeers(r.URL.Query().Get("consistency"), len(peers))
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Error("failed to parse read consistency")
+		writeError(w, err)
+		return err
+	}
+
+	// Reads of the LocalConsistency are served
+	// from the local node kept in sync by Gossip
+	var synced int
+	if required > 0 {
+		synced, err = syncPeers(peers, required)
+		if err != nil && !errors.Is(err, ErrUnavailable) {
+			log.WithFields(log.Fields{"error": err}).Error("failed to sync with incompatible peer")
+			writeError(w, err)
+			return err
+		}
+	}
+
+	w.Header().Set(ReplicasHeader, strconv.Itoa(synced+1))
+
+	// Return ErrUnavailable if fewer
+	// peers than required were synced
+	if synced < required {
+		err = fmt.Errorf("%w: %d of %d replicas contributed, %d required", ErrUnavailable, synced+1, len(peers)+1, required+1)
+		log.WithFields(log.Fields{"error": err}).Error("failed to meet read consistency")
+		writeError(w, err)
+		return err
+	}
+
+	return nil
+}
+
+// readSet syncs the Set of the LocalReplica before a read
+// of the consistency requested, see readConsistency
+func readSet(w http.ResponseWriter, r *http.Request) error {
+	return readConsistency(w, r, func(peers []string, required int) (int, error) {
+		return SyncPeers(LocalReplica, peers, required)
+	})
+}
+
+// readMap syncs the LWWMap before a read of the
+// consistency requested, see readConsistency
+func readMap(w http.ResponseWriter, r *http.Request) error {
+	return readConsistency(w, r, SyncMapPeers)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwmap"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// TestRequiredPeers checks the number of peers required
+// to be synced before a read of each consistency
+func TestRequiredPeers(t *testing.T) {
+	tests := []struct {
+		consistency string
+		peers       int
+		required    int
+	}{
+		{"", 2, 0},
+		{LocalConsistency, 2, 0},
+		{OneConsistency, 0, 0},
+		{OneConsistency, 2, 1},
+		{QuorumConsistency, 0, 0},
+		{QuorumConsistency, 1, 1},
+		{QuorumConsistency, 2, 1},
+		{QuorumConsistency, 3, 2},
+		{QuorumConsistency, 4, 2},
+		{AllConsistency, 4, 4},
+	}
+
+	for _, test := range tests {
+		required, err := requiredPeers(test.consistency, test.peers)
+		assert.Nil(t, err, test.consistency)
+		assert.Equal(t, test.required, required, test.consistency)
+	}
+
+	_, err := requiredPeers("strong", 2)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+}
+
+// TestReadConsistency checks that reads succeed if enough
+// peers were synced for the consistency requested, return
+// HTTP 503 otherwise and the number of replicas contributing
+func TestReadConsistency(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	replace(t, &LWWMap, lwwmap.Initialize())
+	router := Router()
+
+	// The peers named "down" fail while
+	// the others serve a Set & LWWMap
+	peerSet := crdt.NewLWWSet(lwwset.Initialize())
+	peerSet.Add("xx")
+	peerMap := lwwmap.Initialize()
+	peerMap.Put("xx", "xx")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Host, "down") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Path == "/lwwmap/values" {
+			json.NewEncoder(w).Encode(peerMap)
+			return
+		}
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: peerSet})
+	}))
+	defer server.Close()
+	routePeers(t, server)
+
+	tests := []struct {
+		peers       string
+		consistency string
+		status      int
+		replicas    string
+	}{
+		{"up-1,up-2,down-1", LocalConsistency, http.StatusOK, "1"},
+		{"up-1,up-2,down-1", OneConsistency, http.StatusOK, "2"},
+		{"up-1,up-2,down-1", QuorumConsistency, http.StatusOK, "3"},
+		{"up-1,up-2,down-1", AllConsistency, http.StatusServiceUnavailable, "3"},
+		{"up-1,down-1,down-2", OneConsistency, http.StatusOK, "2"},
+		{"up-1,down-1,down-2", QuorumConsistency, http.StatusServiceUnavailable, "2"},
+		{"down-1,down-2", OneConsistency, http.StatusServiceUnavailable, "1"},
+		{"up-1,up-2", AllConsistency, http.StatusOK, "3"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("PEERS", test.peers)
+
+		for _, url := range []string{"/lwwset/list", "/lwwset/lookup/xx", "/lwwmap/list", "/lwwmap/get/xx"} {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest("GET", url+"?consistency="+test.consistency, nil))
+
+			name := test.peers + " " + test.consistency + " " + url
+			assert.Equal(t, test.status, response.Code, name)
+			assert.Equal(t, test.replicas, response.Header().Get(ReplicasHeader), name)
+		}
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list?consistency=strong", nil))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+// TestReadConsistency_Self checks that the local node listed
+// in PEERS is neither synced with nor counted as a replica
+func TestReadConsistency_Self(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+	var hosts sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts.Store(strings.Split(r.Host, ".")[0], true)
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: crdt.NewLWWSet(lwwset.Initialize())})
+	}))
+	defer server.Close()
+	routePeers(t, server)
+	t.Setenv("REPLICA", "self")
+	t.Setenv("PEERS", "self,up-1,up-2")
+
+	response := httptest.NewRecorder()
+	Router().ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list?consistency=all", nil))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "3", response.Header().Get(ReplicasHeader))
+
+	_, ok := hosts.Load("self")
+	assert.False(t, ok)
+	_, ok = hosts.Load("up-1")
+	assert.True(t, ok)
+}
+
+// TestReadConsistency_Slow checks that a read returns once
+// the required peers were synced without waiting on the others
+func TestReadConsistency_Slow(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+	peerSet := crdt.NewLWWSet(lwwset.Initialize())
+	peerSet.Add("xx")
+
+	// The peers named "slow" only
+	// respond once the test is done
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Host, "slow") {
+			<-release
+		}
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: peerSet})
+	}))
+	defer server.Close()
+	defer close(release)
+	routePeers(t, server)
+	t.Setenv("PEERS", "up-1,up-2,slow-1")
+
+	for _, consistency := range []string{OneConsistency, QuorumConsistency} {
+		response := httptest.NewRecorder()
+		Router().ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/lookup/xx?consistency="+consistency, nil))
+
+		assert.Equal(t, http.StatusOK, response.Code, consistency)
+		assert.JSONEq(t, `{"present":true}`, response.Body.String(), consistency)
+	}
+}
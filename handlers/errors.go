@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwmap"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+	"github.com/el10savio/lwwset-crdt/orset"
+)
+
+// MaxBodySize is the maximum size in
+// bytes of a request body, 1 MiB
+const MaxBodySize = 1 << 20
+
+// MaxReplicateBodySize is the maximum size in bytes of the
+// changes pushed by a peer, 64 MiB, the changes made by a
+// request body of MaxBodySize are larger once encoded
+const MaxReplicateBodySize = 64 << 20
+
+// RequestIDHeader is the HTTP header carrying
+// the ID of a request & its response
+const RequestIDHeader = "X-Request-ID"
+
+var (
+	// ErrInvalidRequest is returned when a request
+	// body or URL query parameter cannot be parsed
+	ErrInvalidRequest = errors.New("invalid request")
+
+	// ErrInvalidEncoding is returned when a value is
+	// given in an encoding other than Base64Encoding
+	ErrInvalidEncoding = errors.New("invalid value encoding provided")
+
+	// ErrBodyTooLarge is returned when a request
+	// body is larger than MaxBodySize
+	ErrBodyTooLarge = errors.New("request body too large")
+
+	// ErrUnavailable is returned when
+	// none of the peers could be synced
+	ErrUnavailable = errors.New("no peer available")
+)
+
+// ErrorResponse is the JSON struct
+// encapsulating an error Response
+type ErrorResponse struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// errorCode is the HTTP status & the
+// code in the Response of an error
+type errorCode struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorCodes holds the errorCode of the errors returned to clients,
+// other errors are returned as HTTP 500 with the code "internal"
+var errorCodes = []errorCode{
+	{ErrInvalidRequest, http.StatusBadRequest, "invalid_request"},
+	{ErrInvalidEncoding, http.StatusBadRequest, "invalid_encoding"},
+	{lwwset.ErrEmptyValue, http.StatusBadRequest, "empty_value"},
+	{orset.ErrEmptyValue, http.StatusBadRequest, "empty_value"},
+	{lwwmap.ErrEmptyKey, http.StatusBadRequest, "empty_key"},
+	{lwwset.ErrInvalidTTL, http.StatusBadRequest, "invalid_ttl"},
+	{lwwset.ErrInvalidOpType, http.StatusBadRequest, "invalid_op_type"},
+	{lwwset.ErrHistoryTruncated, http.StatusNotFound, "history_truncated"},
+	{lwwset.ErrBiasMismatch, http.StatusConflict, "bias_mismatch"},
+	{crdt.ErrTypeMismatch, http.StatusConflict, "type_mismatch"},
+	{ErrBodyTooLarge, http.StatusRequestEntityTooLarge, "body_too_large"},
+	{ErrUnsupported, http.StatusNotImplemented, "unsupported"},
+	{lwwset.ErrHistoryDisabled, http.StatusNotImplemented, "history_disabled"},
+	{ErrUnavailable, http.StatusServiceUnavailable, "unavailable"},
+	{ErrInsufficientAcks, http.StatusServiceUnavailable, "insufficient_acks"},
+}
+
+// codeOf returns the HTTP status & the
+// code in the Response of the error
+func codeOf(err error) (int, string) {
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			return errorCode.status, errorCode.code
+		}
+	}
+	return http.StatusInternalServerError, "internal"
+}
+
+// writeError writes the error as an ErrorResponse
+// with the HTTP status the error maps to
+func writeError(w http.ResponseWriter, err error) {
+	status, code := codeOf(err)
+
+	JSONResponse, _ := json.Marshal(ErrorResponse{
+		Code:      code,
+		Message:   err.Error(),
+		RequestID: w.Header().Get(RequestIDHeader),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(JSONResponse)
+}
+
+// requestError wraps an error reading or decoding a request
+// body in ErrBodyTooLarge if the body exceeded MaxBodySize
+// or in ErrInvalidRequest otherwise. A body holding the Set
+// of another set type returns crdt.ErrTypeMismatch
+func requestError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return fmt.Errorf("%w: %v", ErrBodyTooLarge, err)
+	}
+	if errors.Is(err, crdt.ErrTypeMismatch) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+}
+
+// RequestID is the middleware to identify the incoming request by the
+// ID given in its RequestIDHeader, or a new random ID if not given
+// The ID is returned in the RequestIDHeader of the response
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			id := make([]byte, 8)
+			rand.Read(id)
+			requestID = hex.EncodeToString(id)
+			r.Header.Set(RequestIDHeader, requestID)
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwmap"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+	"github.com/el10savio/lwwset-crdt/orset"
+)
+
+// routePeers routes the requests sent to the peers
+// to the given server for the rest of the test
+func routePeers(t *testing.T, server *httptest.Server) {
+	transport := http.DefaultTransport
+	http.DefaultTransport = &http.Transport{
+		DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}
+	t.Cleanup(func() { http.DefaultTransport = transport })
+	t.Setenv("PEERS", "peer")
+}
+
+// replace sets the given variable to the value for the
+// rest of the test, restoring its previous value after
+func replace[T any](t *testing.T, variable *T, value T) {
+	previous := *variable
+	*variable = value
+	t.Cleanup(func() { *variable = previous })
+}
+
+// TestCodeOf checks that errors, even when wrapped,
+// map to their HTTP status & code and that other
+// errors map to HTTP 500
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{ErrInvalidRequest, http.StatusBadRequest, "invalid_request"},
+		{ErrInvalidEncoding, http.StatusBadRequest, "invalid_encoding"},
+		{lwwset.ErrEmptyValue, http.StatusBadRequest, "empty_value"},
+		{orset.ErrEmptyValue, http.StatusBadRequest, "empty_value"},
+		{lwwmap.ErrEmptyKey, http.StatusBadRequest, "empty_key"},
+		{lwwset.ErrInvalidTTL, http.StatusBadRequest, "invalid_ttl"},
+		{lwwset.ErrInvalidOpType, http.StatusBadRequest, "invalid_op_type"},
+		{lwwset.ErrHistoryTruncated, http.StatusNotFound, "history_truncated"},
+		{lwwset.ErrBiasMismatch, http.StatusConflict, "bias_mismatch"},
+		{crdt.ErrTypeMismatch, http.StatusConflict, "type_mismatch"},
+		{ErrBodyTooLarge, http.StatusRequestEntityTooLarge, "body_too_large"},
+		{ErrUnsupported, http.StatusNotImplemented, "unsupported"},
+		{lwwset.ErrHistoryDisabled, http.StatusNotImplemented, "history_disabled"},
+		{ErrUnavailable, http.StatusServiceUnavailable, "unavailable"},
+		{ErrInsufficientAcks, http.StatusServiceUnavailable, "insufficient_acks"},
+		{errors.New("failure"), http.StatusInternalServerError, "internal"},
+	}
+
+	for _, test := range tests {
+		status, code := codeOf(fmt.Errorf("wrapped: %w", test.err))
+		assert.Equal(t, test.status, status, test.err.Error())
+		assert.Equal(t, test.code, code, test.err.Error())
+	}
+}
+
+// TestRequestID checks that the request ID given is returned
+// and that a new request ID is returned if none is given
+func TestRequestID(t *testing.T) {
+	router := Router()
+
+	request := httptest.NewRequest("GET", "/lwwset/list", nil)
+	request.Header.Set(RequestIDHeader, "request-1")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	assert.Equal(t, "request-1", response.Header().Get(RequestIDHeader))
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list", nil))
+	assert.NotEmpty(t, response.Header().Get(RequestIDHeader))
+}
+
+// TestErrors checks that the handlers return the HTTP status
+// & the ErrorResponse of the errors caused by the requests
+func TestErrors(t *testing.T) {
+	tooLarge := `{"value":"` + strings.Repeat("x", MaxBodySize) + `"}`
+
+	tests := []struct {
+		setType string
+		method  string
+		url     string
+		body    string
+		status  int
+		code    string
+	}{
+		{LWWSetType, "POST", "/lwwset/add", `{"value":""}`, http.StatusBadRequest, "empty_value"},
+		{ORSetType, "POST", "/lwwset/add", `{"value":""}`, http.StatusBadRequest, "empty_value"},
+		{LWWSetType, "POST", "/lwwset/remove", "", http.StatusBadRequest, "empty_value"},
+		{LWWSetType, "POST", "/lwwset/lookup", `{"value":""}`, http.StatusBadRequest, "empty_value"},
+		{LWWSetType, "POST", "/lwwset/lookup", `{"value":`, http.StatusBadRequest, "invalid_request"},
+		{LWWSetType, "POST", "/lwwset/add", `{"value":"xx","encoding":"hex"}`, http.StatusBadRequest, "invalid_encoding"},
+		{LWWSetType, "POST", "/lwwset/add/xx?ttl=-1s", "", http.StatusBadRequest, "invalid_ttl"},
+		{LWWSetType, "GET", "/lwwset/list?at=yesterday", "", http.StatusBadRequest, "invalid_request"},
+		{LWWSetType, "GET", "/lwwset/delta?since=x", "", http.StatusBadRequest, "invalid_request"},
+		{LWWSetType, "POST", "/lwwset/batch", `{"type":"add"}`, http.StatusBadRequest, "invalid_request"},
+		{LWWSetType, "POST", "/lwwset/add", tooLarge, http.StatusRequestEntityTooLarge, "body_too_large"},
+		{LWWSetType, "POST", "/lwwset/batch", "[" + tooLarge + "]", http.StatusRequestEntityTooLarge, "body_too_large"},
+		{LWWSetType, "POST", "/lwwmap/put/xx", tooLarge, http.StatusRequestEntityTooLarge, "body_too_large"},
+		{LWWSetType, "GET", "/lwwset/history/xx", "", http.StatusNotImplemented, "history_disabled"},
+		{ORSetType, "GET", "/lwwset/delta", "", http.StatusNotImplemented, "unsupported"},
+		{ORSetType, "POST", "/lwwset/add/xx?ttl=1s", "", http.StatusNotImplemented, "unsupported"},
+		{ORSetType, "POST", "/lwwset/add/xx?ttl=1s", `{"metadata":{"owner":"a"}}`, http.StatusNotImplemented, "unsupported"},
+		{LWWSetType, "POST", "/lwwset/replicate", `{"type":"orset","entries":[]}`, http.StatusConflict, "type_mismatch"},
+		{ORSetType, "POST", "/lwwset/replicate", `{"type":"lwwset","add":[],"remove":[]}`, http.StatusConflict, "type_mismatch"},
+	}
+
+	for _, test := range tests {
+		replace(t, &LocalReplica, NewReplica(NewSet(test.setType)))
+
+		request := httptest.NewRequest(test.method, test.url, strings.NewReader(test.body))
+		request.Header.Set(RequestIDHeader, "request-1")
+		response := httptest.NewRecorder()
+		Router().ServeHTTP(response, request)
+
+		var errorResponse ErrorResponse
+		err := json.NewDecoder(response.Body).Decode(&errorResponse)
+
+		name := test.setType + " " + test.method + " " + test.url
+		assert.Nil(t, err, name)
+		assert.Equal(t, test.status, response.Code, name)
+		assert.Equal(t, "application/json", response.Header().Get("Content-Type"), name)
+		assert.Equal(t, test.code, errorResponse.Code, name)
+		assert.NotEmpty(t, errorResponse.Message, name)
+		assert.Equal(t, "request-1", errorResponse.RequestID, name)
+	}
+}
+
+// TestErrors_HistoryTruncated checks that a lookup before the
+// history retained of a value returns HTTP 404
+func TestErrors_HistoryTruncated(t *testing.T) {
+	t.Setenv("HISTORY_LIMIT", "1")
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	for _, url := range []string{"/lwwset/add/xx", "/lwwset/remove/xx", "/lwwset/add/xx"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", url, nil))
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/lookup/xx?at=1", nil))
+
+	var errorResponse ErrorResponse
+	json.NewDecoder(response.Body).Decode(&errorResponse)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "history_truncated", errorResponse.Code)
+}
+
+// TestErrors_Sync checks that reads return HTTP 409 when a peer's
+// Set has a different bias & HTTP 503 when no peer is reachable
+func TestErrors_Sync(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	// The peer serves a Set with
+	// the add bias as its delta
+	peerSet := crdt.NewLWWSet(lwwset.Initialize(lwwset.WithBias(lwwset.AddBias)))
+	peerSet.Add("xx")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: peerSet})
+	}))
+	routePeers(t, server)
+
+	for _, url := range []string{"/lwwset/list?consistency=one", "/lwwset/lookup/xx?consistency=one"} {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", url, nil))
+
+		var errorResponse ErrorResponse
+		json.NewDecoder(response.Body).Decode(&errorResponse)
+
+		assert.Equal(t, http.StatusConflict, response.Code, url)
+		assert.Equal(t, "bias_mismatch", errorResponse.Code, url)
+	}
+
+	server.Close()
+
+	for _, url := range []string{"/lwwset/list?consistency=one", "/lwwset/lookup/xx?consistency=one"} {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", url, nil))
+
+		var errorResponse ErrorResponse
+		json.NewDecoder(response.Body).Decode(&errorResponse)
+
+		assert.Equal(t, http.StatusServiceUnavailable, response.Code, url)
+		assert.Equal(t, "unavailable", errorResponse.Code, url)
+	}
+}
+
+// TestLookup_Response checks that a successful
+// lookup returns HTTP 200 with a JSON body
+func TestLookup_Response(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/lookup/xx", nil))
+
+	var isPresent IsPresent
+	err := json.NewDecoder(response.Body).Decode(&isPresent)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.True(t, isPresent.Present)
+}
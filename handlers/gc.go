@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+var (
+	// peerAcks holds for each peer the latest version of the local
+	// Set in the current Epoch that the peer has merged
+	peerAcks      = map[string]uint64{}
+	peerAcksMutex sync.Mutex
+)
+
+// Acknowledge notes that the peer has merged
+// the local Set up to the given version
+func Acknowledge(peer string, version uint64) {
+	peerAcksMutex.Lock()
+	defer peerAcksMutex.Unlock()
+
+	if version > peerAcks[peer] {
+		peerAcks[peer] = version
+	}
+}
+
+// StableVersion returns the latest version of the
+// local Set that every peer has merged, the local
+// node is not a peer even when listed in PEERS
+func StableVersion() uint64 {
+	peerAcksMutex.Lock()
+	defer peerAcksMutex.Unlock()
+
+	peers := GetRemotePeerList()
+	if len(peers) == 0 {
+		return 0
+	}
+
+	stable := peerAcks[peers[0]]
+	for _, peer := range peers[1:] {
+		if peerAcks[peer] < stable {
+			stable = peerAcks[peer]
+		}
+	}
+	return stable
+}
+
+// CollectGarbage drops the removed values of the Set which every
+// peer has observed, along with the ones older than the safe age if set
+// The peers only acknowledge the versions they merged through delta
+// syncs, so in op replication the removed values are only dropped
+// once older than the safe age
+func CollectGarbage(set crdt.PruneSet) crdt.PruneSet {
+	stable := StableVersion()
+	pruned := set.PruneTombstones(stable)
+
+	// Fallback for peers that
+	// never acknowledge changes
+	if safeAge := GetTombstoneSafeAge(); safeAge > 0 {
+		pruned += set.PruneTombstonesBefore(time.Now().Add(-safeAge))
+	}
+
+	// DEBUG log indicating the number of
+	// removed values dropped if any
+	if pruned != 0 {
+		log.WithFields(log.Fields{
+			"stable": stable,
+			"pruned": pruned,
+		}).Debug("successful lwwset garbage collection")
+	}
+
+	return set
+}
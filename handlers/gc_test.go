@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// acknowledgeDelta requests the delta of the local Set
+// since the version as the peer in the given epoch
+func acknowledgeDelta(peer string, epoch string, version uint64) {
+	url := fmt.Sprintf("/lwwset/delta?since=%d&peer=%s&epoch=%s", version, peer, epoch)
+	Router().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", url, nil))
+}
+
+// tombstones returns the number of removed
+// values held by the Set of the LocalReplica
+func tombstones() int {
+	var removed int
+	LocalReplica.Write(func(set crdt.Set) error {
+		CollectGarbage(set.(crdt.PruneSet))
+		removed = len(set.(*crdt.LWWSet).Set.RemoveNodes())
+		return nil
+	})
+	return removed
+}
+
+// TestStableVersion checks that the stable version is the
+// latest version acknowledged by every peer, excluding the
+// local node listed in PEERS
+func TestStableVersion(t *testing.T) {
+	t.Setenv("REPLICA", "self")
+	t.Setenv("PEERS", "self,peer-1,peer-2")
+	replace(t, &peerAcks, map[string]uint64{})
+
+	assert.Equal(t, uint64(0), StableVersion())
+
+	Acknowledge("peer-1", 3)
+	Acknowledge("peer-2", 5)
+	assert.Equal(t, uint64(3), StableVersion())
+
+	// Acknowledgements never go backwards
+	Acknowledge("peer-1", 1)
+	Acknowledge("peer-1", 6)
+	assert.Equal(t, uint64(5), StableVersion())
+
+	t.Setenv("PEERS", "")
+	assert.Equal(t, uint64(0), StableVersion())
+}
+
+// TestCollectGarbage checks that a removed value is kept until
+// every peer has acknowledged the delta holding its removal
+// and is dropped once they have
+func TestCollectGarbage(t *testing.T) {
+	t.Setenv("REPLICA", "self")
+	t.Setenv("PEERS", "self,peer-1,peer-2")
+	replace(t, &peerAcks, map[string]uint64{})
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+	router := Router()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/remove/xx", nil))
+	assert.Equal(t, 1, tombstones())
+
+	// Acknowledgements of another
+	// epoch are not comparable
+	acknowledgeDelta("peer-1", "other", 2)
+	acknowledgeDelta("peer-2", "other", 2)
+	assert.Equal(t, 1, tombstones())
+
+	// A peer which merged the addition
+	// but not the removal yet holds it
+	acknowledgeDelta("peer-1", Epoch, 2)
+	acknowledgeDelta("peer-2", Epoch, 1)
+	assert.Equal(t, 1, tombstones())
+
+	acknowledgeDelta("peer-2", Epoch, 2)
+	assert.Equal(t, 0, tombstones())
+}
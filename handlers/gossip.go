@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"math/rand"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Gossip periodically syncs the Set of the LocalReplica & the
+// LWWMap with a random subset of fanout peers, so that the
+// changes made on any node spread through the cluster without
+// reads having to wait on the peers
+func Gossip(interval time.Duration, fanout int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		gossip(fanout)
+	}
+}
+
+// gossip runs a single gossip round with fanout
+// random peers, excluding the local node
+func gossip(fanout int) {
+	peers := randomPeers(GetRemotePeerList(), fanout)
+	if len(peers) == 0 {
+		return
+	}
+
+	_, err := SyncPeers(LocalReplica, peers, 0)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err, "peers": peers}).Error("failed gossiping set with peers")
+	}
+
+	_, err = SyncMapPeers(peers, 0)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err, "peers": peers}).Error("failed gossiping lwwmap with peers")
+	}
+}
+
+// randomPeers returns count peers chosen at random
+// from the given peers, or every peer if fewer
+func randomPeers(peers []string, count int) []string {
+	if count >= len(peers) {
+		return peers
+	}
+
+	chosen := make([]string, count)
+	for index, peer := range rand.Perm(len(peers))[:count] {
+		chosen[index] = peers[peer]
+	}
+	return chosen
+}
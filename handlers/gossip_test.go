@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwmap"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// peerServer returns a server serving as the peer a Set holding
+// the given value, counting the requests it receives
+func peerServer(value string, requests *int32) *httptest.Server {
+	peerSet := crdt.NewLWWSet(lwwset.Initialize())
+	peerSet.Add(value)
+
+	peerMap := lwwmap.Initialize()
+	peerMap.Put(value, value)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		if r.URL.Path == "/lwwmap/values" {
+			json.NewEncoder(w).Encode(peerMap)
+			return
+		}
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: peerSet})
+	}))
+}
+
+// TestRandomPeers checks that the peers chosen are
+// distinct peers of the list given, or every peer
+// if fewer than the count given
+func TestRandomPeers(t *testing.T) {
+	peers := []string{"peer-1", "peer-2", "peer-3", "peer-4"}
+
+	for i := 0; i < 10; i++ {
+		chosen := randomPeers(peers, 2)
+
+		assert.Len(t, chosen, 2)
+		assert.NotEqual(t, chosen[0], chosen[1])
+		assert.Subset(t, peers, chosen)
+	}
+
+	assert.Equal(t, peers, randomPeers(peers, 4))
+	assert.Equal(t, peers, randomPeers(peers, 8))
+	assert.Empty(t, randomPeers([]string{}, 2))
+}
+
+// TestGossip checks that a gossip round merges
+// the peer's Set & LWWMap with the local ones
+func TestGossip(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	replace(t, &LWWMap, lwwmap.Initialize())
+
+	var requests int32
+	server := peerServer("xx", &requests)
+	defer server.Close()
+	routePeers(t, server)
+
+	gossip(GetGossipFanout())
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		present, _ := set.Lookup("xx")
+		assert.True(t, present)
+		return nil
+	})
+
+	LWWMapMutex.RLock()
+	value, present, _ := LWWMap.Get("xx")
+	LWWMapMutex.RUnlock()
+	assert.True(t, present)
+	assert.Equal(t, "xx", value)
+}
+
+// TestGossip_Self checks that the local node listed in PEERS
+// is never synced with by gossip rounds & background syncs
+func TestGossip_Self(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	replace(t, &LWWMap, lwwmap.Initialize())
+
+	var hosts sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts.Store(strings.Split(r.Host, ".")[0], true)
+		if r.URL.Path == "/lwwmap/values" {
+			json.NewEncoder(w).Encode(lwwmap.Initialize())
+			return
+		}
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: crdt.NewLWWSet(lwwset.Initialize())})
+	}))
+	defer server.Close()
+	routePeers(t, server)
+	t.Setenv("REPLICA", "self")
+	t.Setenv("PEERS", "self,peer")
+
+	gossip(2)
+	assert.Nil(t, Sync(LocalReplica))
+	assert.Nil(t, SyncMap())
+
+	_, ok := hosts.Load("self")
+	assert.False(t, ok)
+	_, ok = hosts.Load("peer")
+	assert.True(t, ok)
+}
+
+// TestLocalReads checks that reads are served locally
+// without requesting the peers unless syncing is requested
+func TestLocalReads(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	replace(t, &LWWMap, lwwmap.Initialize())
+	router := Router()
+
+	var requests int32
+	server := peerServer("xx", &requests)
+	defer server.Close()
+	routePeers(t, server)
+
+	for _, url := range []string{"/lwwset/list", "/lwwset/lookup/xx", "/lwwmap/list", "/lwwmap/get/xx"} {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, http.StatusOK, response.Code, url)
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/lookup/xx?consistency=all", nil))
+
+	var isPresent IsPresent
+	json.NewDecoder(response.Body).Decode(&isPresent)
+	assert.True(t, isPresent.Present)
+	assert.NotEqual(t, int32(0), atomic.LoadInt32(&requests))
+}
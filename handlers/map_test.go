@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/lwwmap"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// TestMap checks that the keys put & deleted through
+// the LWWMap routes are returned by get & list
+func TestMap(t *testing.T) {
+	replace(t, &LWWMap, lwwmap.Initialize())
+	router := Router()
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest("POST", "/lwwmap/put/xx", strings.NewReader("1")),
+		httptest.NewRequest("POST", "/lwwmap/put/yy", strings.NewReader("2")),
+		httptest.NewRequest("POST", "/lwwmap/put/xx", strings.NewReader("3")),
+		httptest.NewRequest("POST", "/lwwmap/delete/yy", nil),
+	} {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code, request.URL.Path)
+	}
+
+	tests := []struct {
+		key      string
+		expected MapValue
+	}{
+		{"xx", MapValue{Key: "xx", Value: "3", Present: true}},
+		{"yy", MapValue{Key: "yy", Value: "", Present: false}},
+		{"zz", MapValue{Key: "zz", Value: "", Present: false}},
+	}
+
+	for _, test := range tests {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwmap/get/"+test.key, nil))
+
+		var value MapValue
+		json.NewDecoder(response.Body).Decode(&value)
+		assert.Equal(t, http.StatusOK, response.Code, test.key)
+		assert.Equal(t, test.expected, value, test.key)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwmap/list", nil))
+
+	var values map[string]string
+	json.NewDecoder(response.Body).Decode(&values)
+	assert.Equal(t, map[string]string{"xx": "3"}, values)
+}
+
+// TestSyncMap checks that SyncMap merges the LWWMap of
+// a peer keeping the latest put or delete of each key
+// and refuses the LWWMap of a peer with another bias
+func TestSyncMap(t *testing.T) {
+	replace(t, &LWWMap, lwwmap.Initialize())
+	LWWMap.Put("xx", "1")
+	LWWMap.Put("yy", "1")
+
+	peerMap := lwwmap.Initialize()
+	peerMap.Put("xx", "2")
+	peerMap.Put("zz", "2")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(peerMap)
+	}))
+	defer server.Close()
+	routePeers(t, server)
+
+	assert.Nil(t, SyncMap())
+
+	_, values := LWWMap.List()
+	assert.Equal(t, map[string]string{"xx": "2", "yy": "1", "zz": "2"}, values)
+
+	peerMap = lwwmap.Initialize(lwwset.WithBias(lwwset.AddBias))
+	err := SyncMap()
+	assert.True(t, errors.Is(err, lwwset.ErrBiasMismatch))
+}
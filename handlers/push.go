@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+const (
+	// pushBackoff is the delay before retrying a failed
+	// push, doubled after every failure up to pushMaxBackoff
+	pushBackoff    = 100 * time.Millisecond
+	pushMaxBackoff = 10 * time.Second
+
+	// pushRetries is the number of times a failed push is
+	// retried before its changes are left to Gossip
+	pushRetries = 8
+)
+
+// ErrPushRejected is returned when a peer
+// refuses the changes pushed to it
+var ErrPushRejected = errors.New("changes rejected by peer")
+
+var (
+	// Pushers are the Pushers to each peer the changes made
+	// by the local writes are pushed to, started by StartPush
+	// before the node serves requests
+	Pushers []*Pusher
+)
+
+// Pusher pushes the changes made to the local Set to a peer. The
+// changes are not copied on each write, the Pusher notes the version
+// of the Set before the earliest write not pushed yet and reads the
+// changes made since from the Set when pushing them. The changes
+// pushed are then the latest state of the values changed so that
+// a value whose removal was garbage collected since is not pushed
+// back. A failed push is retried with an exponential backoff, the
+// changes which cannot be pushed are left to Gossip to replicate
+type Pusher struct {
+	peer string
+	// ready is signalled when changes are queued
+	// and closed to stop the Pusher
+	ready chan struct{}
+
+	mutex sync.Mutex
+	// pending is set if changes were made since
+	// the version since & are not pushed yet
+	pending bool
+	since   uint64
+}
+
+// NewPusher returns a Pusher to the peer
+func NewPusher(peer string) *Pusher {
+	return &Pusher{peer: peer, ready: make(chan struct{}, 1)}
+}
+
+// StartPush starts a Pusher to each peer other than the local
+// node, the changes made by the local writes are then pushed
+// to every peer
+func StartPush() {
+	for _, peer := range GetRemotePeerList() {
+		pusher := NewPusher(peer)
+		Pushers = append(Pushers, pusher)
+		go pusher.Run()
+	}
+}
+
+// Queue queues the changes made to the local Set since the
+// given version, along with the changes not pushed yet, to
+// be pushed to the peer
+func (pusher *Pusher) Queue(version uint64) {
+	pusher.mutex.Lock()
+	if !pusher.pending || version < pusher.since {
+		pusher.since = version
+	}
+	pusher.pending = true
+	pusher.mutex.Unlock()
+
+	select {
+	case pusher.ready <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the version of the local Set since which the
+// changes queued were made, if any, and clears the changes
+func (pusher *Pusher) next() (uint64, bool) {
+	pusher.mutex.Lock()
+	defer pusher.mutex.Unlock()
+
+	since, pending := pusher.since, pusher.pending
+	pusher.pending = false
+	return since, pending
+}
+
+// Run pushes the changes queued to the peer until the Pusher
+// is stopped, the changes queued while waiting on the peer
+// are pushed together in the following request
+func (pusher *Pusher) Run() {
+	for range pusher.ready {
+		since, pending := pusher.next()
+		if !pending {
+			continue
+		}
+
+		backoff := pushBackoff
+		for retry := 0; ; retry++ {
+			// The changes are read on every attempt
+			// to push the latest state of the values
+			err := SendReplicateRequest(pusher.peer, localChanges(since))
+			if err == nil {
+				break
+			}
+
+			// The changes are dropped if the peer refused them,
+			// if they are too large or after the last retry,
+			// Gossip replicates them
+			if errors.Is(err, ErrPushRejected) || errors.Is(err, ErrBodyTooLarge) || retry == pushRetries {
+				log.WithFields(log.Fields{"error": err, "peer": pusher.peer}).Error("failed pushing changes to peer")
+				break
+			}
+
+			time.Sleep(backoff)
+			backoff = min(2*backoff, pushMaxBackoff)
+		}
+	}
+}
+
+// writeLocal applies a local write to the Set of the LocalReplica
+// and queues the changes it made to be pushed to every peer. The
+// changes of Sets which cannot be replicated by deltas are pushed
+// as the entire Set, a write which did not change a Set replicated
+// by deltas is not pushed
+func writeLocal(write func(set crdt.Set) error) error {
+	return LocalReplica.Write(func(set crdt.Set) error {
+		var version uint64
+		deltaSet, isDeltaSet := set.(crdt.DeltaSet)
+		if isDeltaSet {
+			version = deltaSet.Version()
+		}
+
+		err := write(set)
+		if err != nil {
+			return err
+		}
+		if isDeltaSet && deltaSet.Version() == version {
+			return nil
+		}
+
+		for _, pusher := range Pushers {
+			pusher.Queue(version)
+		}
+		return nil
+	})
+}
+
+// localVersion returns the version of the Set of the LocalReplica,
+// 0 if the Set cannot be replicated by deltas
+func localVersion() uint64 {
+	var version uint64
+	LocalReplica.Read(func(set crdt.Set) error {
+		if deltaSet, ok := set.(crdt.DeltaSet); ok {
+			version = deltaSet.Version()
+		}
+		return nil
+	})
+	return version
+}
+
+// localChanges returns the changes made to the Set of the LocalReplica
+// since the version, or the entire Set if it cannot be replicated by
+// deltas. The changes made since by other writes are included as well
+func localChanges(since uint64) crdt.Set {
+	var changes crdt.Set
+	LocalReplica.Read(func(set crdt.Set) error {
+		if deltaSet, ok := set.(crdt.DeltaSet); ok {
+			changes = deltaSet.DeltaSince(since)
+			return nil
+		}
+
+		changes = set.Empty()
+		return changes.Merge(set)
+	})
+	return changes
+}
+
+// SendReplicateRequest is used to send a POST /lwwset/replicate to
+// a peer node in the cluster to merge the changes with the peer's Set
+// It returns ErrPushRejected if the peer refused the changes and
+// ErrBodyTooLarge if the changes are larger than MaxReplicateBodySize
+func SendReplicateRequest(peer string, changes crdt.Set) error {
+	// Return an error if the peer is nil
+	if peer == "" {
+		return errors.New("empty peer provided")
+	}
+
+	body, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	if len(body) > MaxReplicateBodySize {
+		return fmt.Errorf("%w: %d bytes of changes", ErrBodyTooLarge, len(body))
+	}
+
+	client := http.Client{
+		Timeout: GetPeerTimeout(),
+	}
+
+	response, err := client.Post(peerURL(peer, "/lwwset/replicate"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Changes refused by the peer are not retried, unless
+	// they were too large for the peer which may accept
+	// them once running with a larger limit
+	if response.StatusCode >= 400 && response.StatusCode < 500 && response.StatusCode != http.StatusRequestEntityTooLarge {
+		return fmt.Errorf("%w: received http response status: %d", ErrPushRejected, response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK {
+		return errors.New("received invalid http response status:" + fmt.Sprint(response.StatusCode))
+	}
+
+	return nil
+}
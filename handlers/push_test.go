@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// TestReplicate checks that the changes pushed by a peer are
+// merged and that incompatible or invalid changes are refused
+func TestReplicate(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	changes := crdt.NewLWWSet(lwwset.Initialize())
+	changes.Add("xx")
+	body, _ := json.Marshal(changes)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/replicate", strings.NewReader(string(body))))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		present, _ := set.Lookup("xx")
+		assert.True(t, present)
+		return nil
+	})
+
+	changes = crdt.NewLWWSet(lwwset.Initialize(lwwset.WithBias(lwwset.AddBias)))
+	changes.Add("yy")
+	body, _ = json.Marshal(changes)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/replicate", strings.NewReader(string(body))))
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/replicate", strings.NewReader(`{"add":`)))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	// Changes larger than the request bodies
+	// of the clients are merged
+	changes = crdt.NewLWWSet(lwwset.Initialize())
+	for i := 0; i < 1100; i++ {
+		changes.Add(strings.Repeat("x", 1024) + fmt.Sprint(i))
+	}
+	body, _ = json.Marshal(changes)
+	assert.Greater(t, len(body), MaxBodySize)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/replicate", strings.NewReader(string(body))))
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+// TestPusher_Queue checks that the local writes queue the changes
+// made since the earliest write not pushed yet without copying them
+func TestPusher_Queue(t *testing.T) {
+	for _, setType := range []string{LWWSetType, ORSetType} {
+		replace(t, &LocalReplica, NewReplica(NewSet(setType)))
+		pusher := NewPusher("peer")
+		replace(t, &Pushers, []*Pusher{pusher})
+
+		router := Router()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/yy", nil))
+
+		since, pending := pusher.next()
+		assert.True(t, pending, setType)
+		assert.ElementsMatch(t, []string{"xx", "yy"}, localChanges(since).List(), setType)
+
+		_, pending = pusher.next()
+		assert.False(t, pending, setType)
+	}
+}
+
+// TestPusher_Pruned checks that a value added & then removed is not
+// pushed back once its removal was garbage collected
+func TestPusher_Pruned(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	pusher := NewPusher("peer")
+	replace(t, &Pushers, []*Pusher{pusher})
+
+	router := Router()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/remove/xx", nil))
+
+	LocalReplica.Write(func(set crdt.Set) error {
+		set.(crdt.PruneSet).PruneTombstones(set.(crdt.DeltaSet).Version())
+		return nil
+	})
+
+	since, _ := pusher.next()
+	changes := localChanges(since).(*crdt.LWWSet)
+	assert.Empty(t, changes.Set.AddNodes())
+	assert.Empty(t, changes.Set.RemoveNodes())
+}
+
+// TestStartPush checks that a Pusher is started to each
+// peer except the local node listed in PEERS
+func TestStartPush(t *testing.T) {
+	t.Setenv("REPLICA", "self")
+	t.Setenv("PEERS", "self,peer-1,peer-2")
+	replace(t, &Pushers, nil)
+
+	StartPush()
+	defer func() {
+		for _, pusher := range Pushers {
+			close(pusher.ready)
+		}
+	}()
+
+	peers := []string{}
+	for _, pusher := range Pushers {
+		peers = append(peers, pusher.peer)
+	}
+	assert.ElementsMatch(t, []string{"peer-1", "peer-2"}, peers)
+}
+
+// TestPusher_Run checks that the changes queued are pushed
+// together to the peer, retrying after the peer failed
+func TestPusher_Run(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+	// The peer fails the first request
+	// and then merges the changes
+	var requests int32
+	received := make(chan crdt.Set, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		changes := NewSet(LWWSetType)
+		json.NewDecoder(r.Body).Decode(changes)
+		received <- changes
+	}))
+	defer server.Close()
+	routePeers(t, server)
+
+	pusher := NewPusher("peer")
+	replace(t, &Pushers, []*Pusher{pusher})
+
+	router := Router()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/yy", nil))
+
+	go pusher.Run()
+	defer close(pusher.ready)
+
+	select {
+	case changes := <-received:
+		assert.ElementsMatch(t, []string{"xx", "yy"}, changes.List())
+	case <-time.After(5 * time.Second):
+		t.Fatal("changes not pushed to peer")
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+// TestSendReplicateRequest checks that changes refused by the
+// peer return ErrPushRejected unless they were too large
+func TestSendReplicateRequest(t *testing.T) {
+	var status int32 = http.StatusConflict
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+	routePeers(t, server)
+
+	err := SendReplicateRequest("peer", NewSet(LWWSetType))
+	assert.True(t, errors.Is(err, ErrPushRejected))
+
+	atomic.StoreInt32(&status, http.StatusRequestEntityTooLarge)
+	err = SendReplicateRequest("peer", NewSet(LWWSetType))
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrPushRejected))
+}
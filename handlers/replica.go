@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// ErrUnsupported is returned when the Set served
+// does not support the functionality requested
+var ErrUnsupported = errors.New("operation not supported by set")
+
+// Replica owns the Set served by the node and guards it
+// so that it is safe to use from concurrent requests
+// The Set is only accessed through Read & Write
+type Replica struct {
+	mutex sync.RWMutex
+	set   crdt.Set
+}
+
+// NewReplica returns a Replica owning the given Set
+func NewReplica(set crdt.Set) *Replica {
+	return &Replica{set: set}
+}
+
+// Read calls read with the Set while no write
+// is in progress, read must not change the Set
+func (replica *Replica) Read(read func(set crdt.Set) error) error {
+	replica.mutex.RLock()
+	defer replica.mutex.RUnlock()
+	return read(replica.set)
+}
+
+// Write calls write with the Set while no
+// other read or write is in progress
+func (replica *Replica) Write(write func(set crdt.Set) error) error {
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	return write(replica.set)
+}
+
+// Empty returns a new empty Set of
+// the type of the Set of the Replica
+func (replica *Replica) Empty() crdt.Set {
+	var empty crdt.Set
+	replica.Read(func(set crdt.Set) error {
+		empty = set.Empty()
+		return nil
+	})
+	return empty
+}
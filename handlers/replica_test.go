@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+)
+
+// workers is the number of goroutines
+// sending requests concurrently
+const workers = 8
+
+// iterations is the number of requests
+// sent by each of the goroutines
+const iterations = 50
+
+// TestReplica_Concurrent checks that concurrent adds, removes
+// & lists on a Replica neither race nor lose any write
+// It is meant to be run with go test -race
+func TestReplica_Concurrent(t *testing.T) {
+	replica := NewReplica(NewSet(LWWSetType))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for i := 0; i < iterations; i++ {
+				value := fmt.Sprintf("%d-%d", worker, i)
+				replica.Write(func(set crdt.Set) error {
+					return set.Add(value)
+				})
+				replica.Write(func(set crdt.Set) error {
+					return set.Remove("removed-" + value)
+				})
+				replica.Read(func(set crdt.Set) error {
+					set.List()
+					_, err := set.Lookup(value)
+					return err
+				})
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	var values []string
+	replica.Read(func(set crdt.Set) error {
+		values = set.List()
+		return nil
+	})
+
+	assert.Len(t, values, workers*iterations)
+}
+
+// TestReplica_Handlers checks that concurrent requests to the
+// add, remove, list, lookup & values handlers neither race nor
+// lose any write. It is meant to be run with go test -race
+func TestReplica_Handlers(t *testing.T) {
+	for _, setType := range []string{LWWSetType, ORSetType} {
+		replace(t, &LocalReplica, NewReplica(NewSet(setType)))
+		router := Router()
+
+		var wg sync.WaitGroup
+		for worker := 0; worker < workers; worker++ {
+			wg.Add(1)
+			go func(worker int) {
+				defer wg.Done()
+
+				for i := 0; i < iterations; i++ {
+					value := fmt.Sprintf("%d-%d", worker, i)
+					for _, request := range []*http.Request{
+						httptest.NewRequest("POST", "/lwwset/add/"+value, nil),
+						httptest.NewRequest("POST", "/lwwset/add/removed-"+value, nil),
+						httptest.NewRequest("POST", "/lwwset/remove/removed-"+value, nil),
+						httptest.NewRequest("GET", "/lwwset/lookup/"+value, nil),
+						httptest.NewRequest("GET", "/lwwset/list", nil),
+						httptest.NewRequest("GET", "/lwwset/values", nil),
+					} {
+						response := httptest.NewRecorder()
+						router.ServeHTTP(response, request)
+						assert.Equal(t, http.StatusOK, response.Code, "%s %s", setType, request.URL)
+					}
+				}
+			}(worker)
+		}
+		wg.Wait()
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list", nil))
+
+		var values []string
+		err := json.NewDecoder(response.Body).Decode(&values)
+
+		assert.Nil(t, err)
+		assert.Len(t, values, workers*iterations, setType)
+	}
+}
@@ -3,21 +3,36 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/el10savio/lwwset-crdt/lwwmap"
 	"github.com/el10savio/lwwset-crdt/lwwset"
 )
 
 var (
-	// LWWSet is the LWWSet
-	// data structure initialized
-	LWWSet lwwset.LWWSet
+	// LocalReplica owns the set data
+	// structure initialized of the
+	// SET_TYPE configured, a LWWSet
+	// by default
+	LocalReplica *Replica
+
+	// LWWMap is the LWWMap data structure
+	// initialized, guarded by LWWMapMutex
+	LWWMap      lwwmap.LWWMap
+	LWWMapMutex sync.RWMutex
 )
 
 func init() {
-	LWWSet = lwwset.Initialize()
+	LocalReplica = NewReplica(NewSet(GetSetType()))
+
+	LWWMap = lwwmap.Initialize(
+		lwwset.WithClock(lwwset.NewHLC(nil).WithMaxDrift(GetMaxClockDrift())),
+		lwwset.WithReplica(GetReplica()),
+		lwwset.WithBias(GetBias()),
+	)
 }
 
 // Route defines the Mux
@@ -34,9 +49,22 @@ var Routes = []Route{
 	{"/", "GET", Index},
 	{"/lwwset/list", "GET", List},
 	{"/lwwset/values", "GET", Values},
+	{"/lwwset/delta", "GET", Delta},
+	{"/lwwset/ops", "GET", Ops},
 	{"/lwwset/lookup/{value}", "GET", Lookup},
+	{"/lwwset/history/{value}", "GET", History},
 	{"/lwwset/add/{value}", "POST", Add},
 	{"/lwwset/remove/{value}", "POST", Remove},
+	{"/lwwset/add", "POST", Add},
+	{"/lwwset/remove", "POST", Remove},
+	{"/lwwset/lookup", "POST", Lookup},
+	{"/lwwset/batch", "POST", Batch},
+	{"/lwwset/replicate", "POST", Replicate},
+	{"/lwwmap/list", "GET", MapList},
+	{"/lwwmap/values", "GET", MapValues},
+	{"/lwwmap/get/{key}", "GET", MapGet},
+	{"/lwwmap/put/{key}", "POST", MapPut},
+	{"/lwwmap/delete/{key}", "POST", MapDelete},
 }
 
 // Index is the handler for the path "/"
@@ -49,8 +77,9 @@ func Index(w http.ResponseWriter, r *http.Request) {
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.WithFields(log.Fields{
-			"path":   r.URL,
-			"method": r.Method,
+			"path":       r.URL,
+			"method":     r.Method,
+			"request_id": r.Header.Get(RequestIDHeader),
 		}).Info("incoming request")
 
 		next.ServeHTTP(w, r)
@@ -68,7 +97,7 @@ func Router() *mux.Router {
 		).Methods(route.Method)
 	}
 
-	router.Use(Logger)
+	router.Use(RequestID, Logger)
 
 	return router
 }
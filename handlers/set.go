@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+	"github.com/el10savio/lwwset-crdt/orset"
+)
+
+const (
+	// LWWSetType serves a LWWSet behind the /lwwset routes
+	LWWSetType = "lwwset"
+
+	// ORSetType serves an ORSet behind the /lwwset routes
+	ORSetType = "orset"
+)
+
+// NewSet returns the empty Set of the type served
+func NewSet(setType string) crdt.Set {
+	if setType == ORSetType {
+		return crdt.NewORSet(orset.Initialize())
+	}
+
+	// Timestamp values with a Hybrid Logical Clock so
+	// causality holds across node clocks drifting up to
+	// the maximum drift, which bounds the peer timestamps.
+	// Timestamp ties between two additions or two removals
+	// are broken with the replica ID, and ties between an
+	// addition & a removal with the bias configured
+	opts := []lwwset.Option{
+		lwwset.WithClock(lwwset.NewHLC(nil).WithMaxDrift(GetMaxClockDrift())),
+		lwwset.WithReplica(GetReplica()),
+		lwwset.WithBias(GetBias()),
+	}
+
+	// Record the operations in the op log in op-based
+	// replication, peers syncing the operations of a
+	// node without the op log merge its entire Set so
+	// the cluster can move from one mode to the other
+	if GetReplicationMode() == OpReplication {
+		opts = append(opts, lwwset.WithOpLog(GetOpLogLimit()))
+	}
+
+	// Record the history of
+	// each value if enabled
+	if limit := GetHistoryLimit(); limit > 0 {
+		opts = append(opts, lwwset.WithHistory(limit))
+	}
+
+	return crdt.NewLWWSet(lwwset.Initialize(opts...))
+}
@@ -5,79 +5,412 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwmap"
 	"github.com/el10savio/lwwset-crdt/lwwset"
 )
 
-// Sync merges multiple LWWSet present in a network to get them in sync
-// It does so by obtaining the LWWSet from each node in the cluster
-// and performs a merge operation with the local LWWSet
-func Sync(LWWSet lwwset.LWWSet) (lwwset.LWWSet, error) {
-	// Obtain addresses of peer nodes in the cluster
-	peers := GetPeerList()
+const (
+	// StateReplication syncs the Set by merging
+	// the changes made to the peers' Sets
+	StateReplication = "state"
 
-	// Return the local LWWSet back if no peers
-	// are present along with an error
+	// OpReplication syncs the Set by applying
+	// the operations in the peers' op logs
+	OpReplication = "op"
+)
+
+// peerVersion is the epoch & version, or op log
+// offset, of a peer's Set at the last successful sync
+type peerVersion struct {
+	epoch   string
+	version uint64
+}
+
+var (
+	// peerVersions holds the peerVersion of each peer
+	// and peerOffsets the op log offset of each peer
+	peerVersions      = map[string]peerVersion{}
+	peerOffsets       = map[string]peerVersion{}
+	peerVersionsMutex sync.Mutex
+)
+
+// Sync merges the Sets of every other node in the cluster with
+// the Set of the Replica to get them in sync, see SyncPeers
+func Sync(replica *Replica) error {
+	_, err := SyncPeers(replica, GetRemotePeerList(), 0)
+	return err
+}
+
+// SyncPeers merges the Sets of the given peers with the Set of the
+// Replica to get them in sync. It does so by obtaining the changes
+// made to the Set of each peer since the last sync and merging them
+// with the Set of the Replica, either as a delta or as operations
+// when in OpReplication. Sets which support neither are synced by
+// merging the entire peer's Set. The peers are requested concurrently
+// without holding the Replica so that the Set is not blocked while
+// waiting on them. When required is positive it returns once required
+// peers were synced, leaving the other peers to be merged once they
+// respond. It returns the number of peers synced along with the error
+// of a peer whose Set is not compatible, or ErrUnavailable if none of
+// the peers could be synced
+func SyncPeers(replica *Replica, peers []string, required int) (int, error) {
+	// Return an error if no
+	// peers are present
+	if len(peers) == 0 {
+		return 0, errors.New("nil peers present")
+	}
+
+	// The type of the Set owned by the Replica
+	// does not change so it is checked once
+	var isOpSet, isDeltaSet bool
+	replica.Read(func(set crdt.Set) error {
+		_, isOpSet = set.(crdt.OpSet)
+		_, isDeltaSet = set.(crdt.DeltaSet)
+		return nil
+	})
+
+	// Obtain from each peer the
+	// changes made to its Set
+	synced, failed := syncEach(peers, required, func(peer string) error {
+		switch {
+		case isOpSet && GetReplicationMode() == OpReplication:
+			return syncOps(replica, peer, isDeltaSet)
+		case isDeltaSet:
+			return syncDelta(replica, peer)
+		default:
+			return syncState(replica, peer)
+		}
+	})
+
+	replica.Write(func(set crdt.Set) error {
+		// Drop the removed values which
+		// every peer has observed
+		if pruneSet, ok := set.(crdt.PruneSet); ok {
+			CollectGarbage(pruneSet)
+		}
+
+		// DEBUG log in the case of success
+		// indicating the new Set
+		log.WithFields(log.Fields{
+			"set": set,
+		}).Debug("successful set sync")
+
+		return nil
+	})
+
+	return syncResult(synced, failed)
+}
+
+// SyncMap merges the LWWMaps of every other node in the cluster
+// with the local LWWMap to get them in sync, see SyncMapPeers
+func SyncMap() error {
+	_, err := SyncMapPeers(GetRemotePeerList(), 0)
+	return err
+}
+
+// SyncMapPeers merges the LWWMaps of the given peers with the local
+// LWWMap to get them in sync. It does so by obtaining the LWWMap from
+// each peer concurrently and performs a merge operation with the local
+// LWWMap while holding the LWWMapMutex. When required is positive it
+// returns once required peers were synced, see SyncPeers. It returns
+// the number of peers synced along with the error of a peer whose
+// LWWMap is not compatible, or ErrUnavailable if none of the peers
+// could be synced
+func SyncMapPeers(peers []string, required int) (int, error) {
+	// Return an error if no
+	// peers are present
 	if len(peers) == 0 {
-		return LWWSet, errors.New("nil peers present")
+		return 0, errors.New("nil peers present")
 	}
 
-	// Iterate over the peer list and send a /lwwset/values GET request
-	// to each peer to obtain its LWWSet
+	// Send a /lwwmap/values GET request to each peer to obtain
+	// its LWWMap and merge it with our local LWWMap, which is
+	// refused if the peer was configured with a different bias
+	synced, failed := syncEach(peers, required, func(peer string) error {
+		var peerLWWMap lwwmap.LWWMap
+
+		err := sendPeerRequest(peer, "/lwwmap/values", &peerLWWMap)
+		if err != nil {
+			return err
+		}
+		return mergeMap(peerLWWMap)
+	})
+
+	// DEBUG log in the case of success
+	LWWMapMutex.RLock()
+	log.WithFields(log.Fields{
+		"map": LWWMap,
+	}).Debug("successful lwwmap sync")
+	LWWMapMutex.RUnlock()
+
+	return syncResult(synced, failed)
+}
+
+// syncEach calls syncPeer with each peer concurrently and returns the
+// number of peers synced along with the error syncing each peer which
+// failed. It waits on every peer unless required is positive, in which
+// case it returns as soon as required peers were synced and the peers
+// still syncing complete in the background
+func syncEach(peers []string, required int, syncPeer func(peer string) error) (int, []error) {
+	// Buffered so that the peers completing
+	// after the return do not block
+	results := make(chan error, len(peers))
+
 	for _, peer := range peers {
-		peerLWWSet, err := SendListRequest(peer)
+		go func() {
+			err := syncPeer(peer)
+			if err != nil {
+				log.WithFields(log.Fields{"error": err, "peer": peer}).Error("failed syncing with peer")
+				err = fmt.Errorf("peer %s: %w", peer, err)
+			}
+			results <- err
+		}()
+	}
+
+	synced, failed := 0, []error{}
+	for range peers {
+		err := <-results
 		if err != nil {
-			log.WithFields(log.Fields{"error": err, "peer": peer}).Error("failed sending lwwset values request")
+			failed = append(failed, err)
 			continue
 		}
 
-		// Merge the peer's LWWSet with our local LWWSet
-		LWWSet = lwwset.Merge(LWWSet, peerLWWSet)
+		synced++
+		if required > 0 && synced >= required {
+			break
+		}
 	}
 
-	// DEBUG log in the case of success
-	// indicating the new LWWSet
+	return synced, failed
+}
+
+// syncResult returns the number of peers synced along with the error
+// of the first failed peer configured with a different bias or set
+// type, or ErrUnavailable if none of the peers could be synced
+func syncResult(synced int, failed []error) (int, error) {
+	for _, err := range failed {
+		if errors.Is(err, lwwset.ErrBiasMismatch) || errors.Is(err, crdt.ErrTypeMismatch) {
+			return synced, err
+		}
+	}
+
+	if synced == 0 {
+		return 0, fmt.Errorf("%w: %w", ErrUnavailable, errors.Join(failed...))
+	}
+
+	return synced, nil
+}
+
+// mergeMap merges the peer's LWWMap into our local LWWMap in place
+// if it was configured with the same bias
+func mergeMap(peerLWWMap lwwmap.LWWMap) error {
+	LWWMapMutex.Lock()
+	defer LWWMapMutex.Unlock()
+
+	err := LWWMap.Compatible(peerLWWMap)
+	if err != nil {
+		return err
+	}
+
+	LWWMap.Join(peerLWWMap)
+	return nil
+}
+
+// syncState merges the peer's entire Set with the Set of the Replica
+func syncState(replica *Replica, peer string) error {
+	// Send a /lwwset/values GET request
+	// to the peer to obtain its Set
+	peerSet := replica.Empty()
+	err := sendPeerRequest(peer, "/lwwset/values", peerSet)
+	if err != nil {
+		return err
+	}
+
+	// Merge the peer's Set with our local Set
+	return replica.Write(func(set crdt.Set) error {
+		return set.Merge(peerSet)
+	})
+}
+
+// syncDelta merges the changes made to the peer's Set
+// since the last sync with the Set of the Replica
+func syncDelta(replica *Replica, peer string) error {
+	last := getPeerVersion(peerVersions, peer)
+
+	// Send a /lwwset/delta GET request to the peer to
+	// obtain the changes made to its Set since then
+	delta, err := SendListRequest(replica.Empty(), peer, last.epoch, last.version)
+	if err != nil {
+		return err
+	}
+
+	// The peer restarted since the last sync so its
+	// versions are not comparable with the last version
+	// and its entire Set is requested instead
+	if last.version != 0 && delta.Epoch != last.epoch {
+		delta, err = SendListRequest(replica.Empty(), peer, "", 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Merge the peer's changes with our local Set, which
+	// is refused if the peer's Set is not compatible, and
+	// note the peer's version we are in sync with
+	err = replica.Write(func(set crdt.Set) error {
+		return set.Merge(delta.Delta)
+	})
+	if err != nil {
+		return err
+	}
+	setPeerVersion(peerVersions, peer, peerVersion{epoch: delta.Epoch, version: delta.Version})
+
+	return nil
+}
+
+// syncOps applies the operations in the peer's op log
+// since the last sync to the Set of the Replica
+func syncOps(replica *Replica, peer string, isDeltaSet bool) error {
+	last := getPeerVersion(peerOffsets, peer)
+
+	// Send a /lwwset/ops GET request to the peer to obtain
+	// the operations in its op log since then
+	ops, err := SendOpsRequest(peer, last.epoch, int(last.version))
+	if err != nil {
+		return err
+	}
+
+	// The op log only holds the latest operations since
+	// the peer started, so the first sync with the peer
+	// after it started, or after it dropped operations not
+	// applied yet, merges its entire Set to catch up with
+	// the changes made before or replicated by state
+	if ops.Epoch != last.epoch || ops.Truncated {
+		if isDeltaSet {
+			err = syncDelta(replica, peer)
+		} else {
+			err = syncState(replica, peer)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply the peer's operations not applied yet
+	// and note the peer's offset we are in sync with
+	var applied int
+	err = replica.Write(func(set crdt.Set) error {
+		opSet, ok := set.(crdt.OpSet)
+		if !ok {
+			return ErrUnsupported
+		}
+
+		applied = opSet.ApplyOps(ops.Ops...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	setPeerVersion(peerOffsets, peer, peerVersion{epoch: ops.Epoch, version: uint64(ops.Offset)})
+
+	// DEBUG log indicating the
+	// number of operations applied
 	log.WithFields(log.Fields{
-		"set": LWWSet,
-	}).Debug("successful lwwset sync")
+		"peer":    peer,
+		"applied": applied,
+	}).Debug("successful set ops sync")
 
-	// Return the synced new LWWSet
-	return LWWSet, nil
+	return nil
 }
 
-// SendListRequest is used to send a GET /lwwset/values
-// to peer nodes in the cluster
-func SendListRequest(peer string) (lwwset.LWWSet, error) {
-	var _lwwset lwwset.LWWSet
+// getPeerVersion returns the peerVersion of
+// the peer at the last successful sync
+func getPeerVersion(versions map[string]peerVersion, peer string) peerVersion {
+	peerVersionsMutex.Lock()
+	defer peerVersionsMutex.Unlock()
+	return versions[peer]
+}
 
-	// Return an empty LWWSet followed by an error if the peer is nil
-	if peer == "" {
-		return _lwwset, errors.New("empty peer provided")
+// setPeerVersion notes the peerVersion of
+// the peer at the last successful sync
+func setPeerVersion(versions map[string]peerVersion, peer string, version peerVersion) {
+	peerVersionsMutex.Lock()
+	defer peerVersionsMutex.Unlock()
+	versions[peer] = version
+}
+
+// SendListRequest is used to send a GET /lwwset/delta to peer nodes in
+// the cluster to obtain the changes since the given version of the peer's
+// epoch, acknowledging to the peer that we merged up to that version
+// The changes are decoded into the given empty Set
+func SendListRequest(empty crdt.Set, peer string, epoch string, since uint64) (DeltaResponse, error) {
+	delta := DeltaResponse{Delta: empty}
+
+	query := url.Values{}
+	query.Set("since", fmt.Sprint(since))
+	query.Set("epoch", epoch)
+	query.Set("peer", GetReplica())
+
+	err := sendPeerRequest(peer, "/lwwset/delta?"+query.Encode(), &delta)
+	if err != nil {
+		return DeltaResponse{}, err
 	}
 
-	// Resolve the Peer ID and network to generate the request URL
-	url := fmt.Sprintf("http://%s.%s/lwwset/values", peer, GetNetwork())
-	response, err := SendRequest(url)
+	// Return the decoded peer's delta
+	return delta, nil
+}
+
+// SendOpsRequest is used to send a GET /lwwset/ops to peer nodes
+// in the cluster to obtain the operations in the peer's op log
+// since the given offset of the peer's epoch
+func SendOpsRequest(peer string, epoch string, since int) (OpsResponse, error) {
+	var ops OpsResponse
+
+	query := url.Values{}
+	query.Set("since", fmt.Sprint(since))
+	query.Set("epoch", epoch)
+
+	err := sendPeerRequest(peer, "/lwwset/ops?"+query.Encode(), &ops)
 	if err != nil {
-		return _lwwset, err
+		return OpsResponse{}, err
 	}
 
-	// Return an empty LWWSet followed by an error
-	// if the peer's response is not HTTP 200 OK
-	if response.StatusCode != http.StatusOK {
-		return _lwwset, errors.New("received invalid http response status:" + fmt.Sprint(response.StatusCode))
+	// Return the decoded peer's ops
+	return ops, nil
+}
+
+// peerURL resolves the Peer ID and network
+// to generate the URL of the path on the peer
+func peerURL(peer string, path string) string {
+	return fmt.Sprintf("http://%s.%s%s", peer, GetNetwork(), path)
+}
+
+// sendPeerRequest sends a GET request for the path to
+// the peer and decodes the JSON response into value
+func sendPeerRequest(peer string, path string, value interface{}) error {
+	// Return an error if the peer is nil
+	if peer == "" {
+		return errors.New("empty peer provided")
 	}
 
-	// Decode the peer's LWWSet to be usable by our local LWWSet
-	var lwwset lwwset.LWWSet
-	err = json.NewDecoder(response.Body).Decode(&lwwset)
+	response, err := SendRequest(peerURL(peer, path))
 	if err != nil {
-		return _lwwset, err
+		return err
+	}
+	defer response.Body.Close()
+
+	// Return an error if the peer's
+	// response is not HTTP 200 OK
+	if response.StatusCode != http.StatusOK {
+		return errors.New("received invalid http response status:" + fmt.Sprint(response.StatusCode))
 	}
 
-	// Return the decoded peer's LWWSet
-	_lwwset = lwwset
-	return _lwwset, nil
+	// Decode the peer's response
+	return json.NewDecoder(response.Body).Decode(value)
 }
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// TestNewSet_OpLog checks that the operations of the
+// Set are only recorded in op-based replication
+func TestNewSet_OpLog(t *testing.T) {
+	set := NewSet(LWWSetType)
+	set.Add("xx")
+	ops, _, truncated := set.(crdt.OpSet).OpsSince(0)
+
+	assert.Empty(t, ops)
+	assert.True(t, truncated)
+
+	t.Setenv("REPLICATION", OpReplication)
+	set = NewSet(LWWSetType)
+	set.Add("xx")
+	ops, _, truncated = set.(crdt.OpSet).OpsSince(0)
+
+	assert.Len(t, ops, 1)
+	assert.False(t, truncated)
+}
+
+// TestSyncOps_Truncated checks that a sync in op-based
+// replication merges the peer's Set when the peer dropped
+// operations not applied yet from its op log
+func TestSyncOps_Truncated(t *testing.T) {
+	t.Setenv("REPLICATION", OpReplication)
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+
+	var deltas int32
+	peerSet := crdt.NewLWWSet(lwwset.Initialize())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/lwwset/ops" {
+			json.NewEncoder(w).Encode(OpsResponse{Epoch: "peer", Offset: 10, Ops: []crdt.Op{}, Truncated: true})
+			return
+		}
+		atomic.AddInt32(&deltas, 1)
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: "peer", Version: 1, Delta: peerSet})
+	}))
+	defer server.Close()
+	routePeers(t, server)
+
+	peerSet.Add("xx")
+	assert.Nil(t, Sync(LocalReplica))
+	peerSet.Add("yy")
+	assert.Nil(t, Sync(LocalReplica))
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		assert.Equal(t, []string{"xx", "yy"}, set.List())
+		return nil
+	})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&deltas))
+}
+
+// deltaRequest is the epoch & version
+// of a delta requested from a peer
+type deltaRequest struct {
+	epoch string
+	since string
+}
+
+// deltaPeer returns a peer serving the given Set at the given epoch
+// & version, and records the deltas requested from it
+func deltaPeer(t *testing.T, peerSet crdt.Set, epoch *string, version uint64) *[]deltaRequest {
+	var mutex sync.Mutex
+	requests := []deltaRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, deltaRequest{epoch: r.URL.Query().Get("epoch"), since: r.URL.Query().Get("since")})
+		mutex.Unlock()
+
+		json.NewEncoder(w).Encode(DeltaResponse{Epoch: *epoch, Version: version, Delta: peerSet})
+	}))
+	t.Cleanup(server.Close)
+	routePeers(t, server)
+
+	return &requests
+}
+
+// TestSyncDelta_Since checks that a sync requests the changes
+// made to the peer's Set since the version of the last sync
+func TestSyncDelta_Since(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	replace(t, &peerVersions, map[string]peerVersion{})
+
+	epoch := "peer-1"
+	peerSet := crdt.NewLWWSet(lwwset.Initialize())
+	peerSet.Add("xx")
+	requests := deltaPeer(t, peerSet, &epoch, 3)
+
+	assert.Nil(t, Sync(LocalReplica))
+	assert.Nil(t, Sync(LocalReplica))
+
+	expectedValue := []deltaRequest{{epoch: "", since: "0"}, {epoch: "peer-1", since: "3"}}
+	assert.Equal(t, expectedValue, *requests)
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		assert.Equal(t, []string{"xx"}, set.List())
+		return nil
+	})
+}
+
+// TestSyncDelta_EpochChange checks that a sync with a peer which
+// restarted since the last sync requests the peer's entire Set
+func TestSyncDelta_EpochChange(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	replace(t, &peerVersions, map[string]peerVersion{})
+
+	epoch := "peer-1"
+	peerSet := crdt.NewLWWSet(lwwset.Initialize())
+	peerSet.Add("xx")
+	requests := deltaPeer(t, peerSet, &epoch, 3)
+
+	assert.Nil(t, Sync(LocalReplica))
+
+	epoch = "peer-2"
+	peerSet.Add("yy")
+	assert.Nil(t, Sync(LocalReplica))
+
+	expectedValue := []deltaRequest{{epoch: "", since: "0"}, {epoch: "peer-1", since: "3"}, {epoch: "", since: "0"}}
+	assert.Equal(t, expectedValue, *requests)
+	assert.Equal(t, peerVersion{epoch: "peer-2", version: 3}, getPeerVersion(peerVersions, "peer"))
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		assert.Equal(t, []string{"xx", "yy"}, set.List())
+		return nil
+	})
+}
+
+// TestDelta checks that the delta served holds only the changes
+// made since the version requested, or the entire Set when the
+// version requested is ahead of the Set's version
+func TestDelta(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/lwwset/add/yy", nil))
+
+	tests := []struct {
+		since    string
+		expected []string
+	}{
+		{"0", []string{"xx", "yy"}},
+		{"1", []string{"yy"}},
+		{"2", []string{}},
+		{"10", []string{"xx", "yy"}},
+	}
+
+	for _, test := range tests {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/delta?since="+test.since, nil))
+
+		delta := DeltaResponse{Delta: LocalReplica.Empty()}
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&delta), test.since)
+		assert.Equal(t, Epoch, delta.Epoch, test.since)
+		assert.Equal(t, uint64(2), delta.Version, test.since)
+		assert.Equal(t, test.expected, delta.Delta.List(), test.since)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/crdt"
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// TestSweep checks that the values expired are removed
+// and that their removal is queued to the peers
+func TestSweep(t *testing.T) {
+	clock := lwwset.NewManualClock(time.Unix(0, 1))
+	replace(t, &LocalReplica, NewReplica(crdt.NewLWWSet(lwwset.Initialize(lwwset.WithClock(clock)))))
+	pusher := NewPusher("peer")
+	replace(t, &Pushers, []*Pusher{pusher})
+	router := Router()
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/add/xx?ttl=1s", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	pusher.next()
+
+	// Nothing expired yet, nothing is pushed
+	assert.Nil(t, sweep())
+	_, pending := pusher.next()
+	assert.False(t, pending)
+
+	clock.Advance(2 * time.Second)
+	assert.Nil(t, sweep())
+
+	since, pending := pusher.next()
+	assert.True(t, pending)
+	changes := localChanges(since).(*crdt.LWWSet)
+	assert.Equal(t, "xx", changes.Set.RemoveNodes()[0].Value)
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		present, _ := set.Lookup("xx")
+		assert.False(t, present)
+		return nil
+	})
+
+	replace(t, &LocalReplica, NewReplica(NewSet(ORSetType)))
+	assert.Equal(t, ErrUnsupported, sweep())
+}
+
+// TestAdd_TTLMetadata checks that a value
+// is added with both a TTL & metadata
+func TestAdd_TTLMetadata(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/add/xx?ttl=1h", strings.NewReader(`{"metadata":{"owner":"a"}}`)))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list?metadata=true", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `"owner":"a"`)
+
+	LocalReplica.Read(func(set crdt.Set) error {
+		nodes := set.(*crdt.LWWSet).Set.AddNodes()
+		assert.Equal(t, 1, len(nodes))
+		assert.False(t, nodes[0].Expiry.IsZero())
+		return nil
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/el10savio/lwwset-crdt/lwwset"
 )
 
 // GetPeerList Obtains Peer List
@@ -17,12 +20,161 @@ func GetPeerList() []string {
 	return strings.Split(os.Getenv("PEERS"), ",")
 }
 
+// GetRemotePeerList Obtains the Peer List From
+// Environment Variable without the local node
+func GetRemotePeerList() []string {
+	peers := []string{}
+	for _, peer := range GetPeerList() {
+		if peer != GetReplica() {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
 // GetNetwork Obtains Network
 // From Environment Variable
 func GetNetwork() string {
 	return os.Getenv("NETWORK") + ":8080"
 }
 
+// GetReplica Obtains the Replica ID of the node
+// From Environment Variable or the hostname
+func GetReplica() string {
+	if os.Getenv("REPLICA") != "" {
+		return os.Getenv("REPLICA")
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// GetBias Obtains the LWWSet Bias
+// From Environment Variable
+func GetBias() lwwset.Bias {
+	if os.Getenv("BIAS") == string(lwwset.AddBias) {
+		return lwwset.AddBias
+	}
+	return lwwset.RemoveBias
+}
+
+// GetReplicationMode Obtains the replication
+// mode, "state" or "op", From Environment Variable
+func GetReplicationMode() string {
+	if os.Getenv("REPLICATION") == OpReplication {
+		return OpReplication
+	}
+	return StateReplication
+}
+
+// GetSetType Obtains the type of set served,
+// "lwwset" or "orset", From Environment Variable
+func GetSetType() string {
+	if os.Getenv("SET_TYPE") == ORSetType {
+		return ORSetType
+	}
+	return LWWSetType
+}
+
+// GetHistoryLimit Obtains the number of operations
+// kept in the history of each value From Environment
+// Variable. History is disabled if not set or invalid
+func GetHistoryLimit() int {
+	limit, err := strconv.Atoi(os.Getenv("HISTORY_LIMIT"))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// GetOpLogLimit Obtains the number of operations kept in
+// the op log From Environment Variable, the default limit
+// of the op log is used if not set or invalid
+func GetOpLogLimit() int {
+	limit, err := strconv.Atoi(os.Getenv("OP_LOG_LIMIT"))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// GetSweepInterval Obtains the interval between the
+// sweeps of the values expired From Environment
+// Variable, defaulting to a second if not set or invalid
+func GetSweepInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("TTL_SWEEP_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Second
+	}
+	return interval
+}
+
+// GetTombstoneSafeAge Obtains the age after which
+// removed values are dropped From Environment Variable
+// It is disabled if not set or invalid
+func GetTombstoneSafeAge() time.Duration {
+	safeAge, err := time.ParseDuration(os.Getenv("TOMBSTONE_SAFE_AGE"))
+	if err != nil {
+		return 0
+	}
+	return safeAge
+}
+
+// GetMaxClockDrift Obtains how far ahead of the physical
+// time the timestamps of the peers are followed From
+// Environment Variable, defaulting to a minute if not set
+// or invalid. The drift is not bounded if set to 0
+func GetMaxClockDrift() time.Duration {
+	maxDrift, err := time.ParseDuration(os.Getenv("MAX_CLOCK_DRIFT"))
+	if err != nil || maxDrift < 0 {
+		return lwwset.DefaultMaxDrift
+	}
+	return maxDrift
+}
+
+// GetGossipInterval Obtains the interval between the
+// gossip rounds syncing with the peers From Environment
+// Variable, defaulting to a second if not set or invalid
+func GetGossipInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("GOSSIP_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Second
+	}
+	return interval
+}
+
+// GetGossipFanout Obtains the number of peers synced
+// with in each gossip round From Environment Variable,
+// defaulting to 2 if not set or invalid
+func GetGossipFanout() int {
+	fanout, err := strconv.Atoi(os.Getenv("GOSSIP_FANOUT"))
+	if err != nil || fanout <= 0 {
+		return 2
+	}
+	return fanout
+}
+
+// GetPeerTimeout Obtains the timeout of the requests
+// sent to the peers From Environment Variable,
+// defaulting to 5 seconds if not set or invalid
+func GetPeerTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("PEER_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return 5 * time.Second
+	}
+	return timeout
+}
+
+// GetWriteTimeout Obtains the time a write waits for the
+// peers to acknowledge it From Environment Variable,
+// defaulting to 5 seconds if not set or invalid
+func GetWriteTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("WRITE_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return 5 * time.Second
+	}
+	return timeout
+}
+
 // SendRequest handles sending of an HTTP GET Request
 func SendRequest(url string) (http.Response, error) {
 	if url == "" {
@@ -30,7 +182,7 @@ func SendRequest(url string) (http.Response, error) {
 	}
 
 	client := http.Client{
-		Timeout: time.Duration(5 * 60 * time.Second),
+		Timeout: GetPeerTimeout(),
 	}
 
 	response, err := client.Get(url)
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Base64Encoding is the encoding of the values which
+// are not UTF-8 strings in request & response bodies
+const Base64Encoding = "base64"
+
+// ValueRequest is the JSON struct encapsulating the value in
+// the body of the add, remove & lookup requests. The value
+// is base64 encoded if the encoding is Base64Encoding
+type ValueRequest struct {
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// DecodeValue returns the value of the
+// request decoded from its encoding
+func (request ValueRequest) DecodeValue() (string, error) {
+	switch request.Encoding {
+	case "":
+		return request.Value, nil
+	case Base64Encoding:
+		value, err := base64.StdEncoding.DecodeString(request.Value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+		}
+		return string(value), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidEncoding, request.Encoding)
+	}
+}
+
+// decodeBody decodes the JSON request body into the
+// request if the body is not empty, refusing bodies
+// larger than MaxBodySize
+func decodeBody(w http.ResponseWriter, r *http.Request, request interface{}) error {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize)).Decode(request)
+	if err == nil || err == io.EOF {
+		return nil
+	}
+	return requestError(err)
+}
+
+// requestValue returns the value from the URL params for the
+// path routes or else the value decoded from the request body
+func requestValue(r *http.Request, request ValueRequest) (string, error) {
+	if value, ok := mux.Vars(r)["value"]; ok {
+		return value, nil
+	}
+	return request.DecodeValue()
+}
+
+// encodeValues returns the values encoded in the
+// encoding given, as is if no encoding is given
+func encodeValues(values []string, encoding string) ([]string, error) {
+	switch encoding {
+	case "":
+		return values, nil
+	case Base64Encoding:
+		encoded := make([]string, len(values))
+		for index, value := range values {
+			encoded[index] = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+		return encoded, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEncoding, encoding)
+	}
+}
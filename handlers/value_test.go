@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValueRequest_DecodeValue checks that the value of the
+// request is decoded from the encoding of the request
+func TestValueRequest_DecodeValue(t *testing.T) {
+	value, err := ValueRequest{Value: "a/b?c#d e"}.DecodeValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "a/b?c#d e", value)
+
+	value, err = ValueRequest{Value: base64.StdEncoding.EncodeToString([]byte{0x00, 0xff, '/'}), Encoding: Base64Encoding}.DecodeValue()
+	assert.Nil(t, err)
+	assert.Equal(t, string([]byte{0x00, 0xff, '/'}), value)
+
+	_, err = ValueRequest{Value: "%%%", Encoding: Base64Encoding}.DecodeValue()
+	assert.NotNil(t, err)
+
+	_, err = ValueRequest{Value: "xx", Encoding: "hex"}.DecodeValue()
+	assert.NotNil(t, err)
+}
+
+// TestBodyValues checks that values which cannot be given in the
+// URL path are added, looked up & removed through the request body
+func TestBodyValues(t *testing.T) {
+	binary := string([]byte{0x00, 0xff, 0xfe, '/'})
+	encoded := base64.StdEncoding.EncodeToString([]byte(binary))
+
+	for _, setType := range []string{LWWSetType, ORSetType} {
+		replace(t, &LocalReplica, NewReplica(NewSet(setType)))
+		router := Router()
+
+		serve := func(method, url, body string) *httptest.ResponseRecorder {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(method, url, strings.NewReader(body)))
+			return response
+		}
+
+		lookup := func(body string) bool {
+			var isPresent IsPresent
+			json.NewDecoder(serve("POST", "/lwwset/lookup", body).Body).Decode(&isPresent)
+			return isPresent.Present
+		}
+
+		assert.Equal(t, http.StatusOK, serve("POST", "/lwwset/add", `{"value":"a/b?c#d e"}`).Code, setType)
+		assert.Equal(t, http.StatusOK, serve("POST", "/lwwset/add", `{"value":"`+encoded+`","encoding":"base64"}`).Code, setType)
+		assert.Equal(t, http.StatusOK, serve("POST", "/lwwset/add", `{"value":"`+strings.Repeat("x", 4096)+`"}`).Code, setType)
+
+		assert.True(t, lookup(`{"value":"a/b?c#d e"}`), setType)
+		assert.True(t, lookup(`{"value":"`+encoded+`","encoding":"base64"}`), setType)
+		assert.True(t, lookup(`{"value":"`+strings.Repeat("x", 4096)+`"}`), setType)
+
+		var values []string
+		json.NewDecoder(serve("GET", "/lwwset/list?encoding=base64", "").Body).Decode(&values)
+		assert.Contains(t, values, encoded, setType)
+
+		assert.Equal(t, http.StatusOK, serve("POST", "/lwwset/remove", `{"value":"a/b?c#d e"}`).Code, setType)
+		assert.False(t, lookup(`{"value":"a/b?c#d e"}`), setType)
+		assert.True(t, lookup(`{"value":"`+encoded+`","encoding":"base64"}`), setType)
+	}
+}
+
+// TestBodyValues_Invalid checks that the body endpoints refuse
+// invalid bodies, encodings & empty values
+func TestBodyValues_Invalid(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	for _, url := range []string{"/lwwset/add", "/lwwset/remove", "/lwwset/lookup"} {
+		for _, body := range []string{`{"value":`, `{"value":"%%%","encoding":"base64"}`, `{"value":"xx","encoding":"hex"}`} {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest("POST", url, strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, response.Code, url+" "+body)
+		}
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/list?encoding=hex", nil))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+// TestPathValues checks that the path routes
+// are still served along with the body endpoints
+func TestPathValues(t *testing.T) {
+	replace(t, &LocalReplica, NewReplica(NewSet(LWWSetType)))
+	router := Router()
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/add/xx", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("GET", "/lwwset/lookup/xx", nil))
+
+	var isPresent IsPresent
+	json.NewDecoder(response.Body).Decode(&isPresent)
+	assert.True(t, isPresent.Present)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest("POST", "/lwwset/remove/xx", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+}
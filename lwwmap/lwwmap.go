@@ -0,0 +1,157 @@
+package lwwmap
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+// package lwwmap implements the LWWMap (Last Writer Wins Element Map) CRDT data type, a LWW register
+// for each key, along with the functionality to put, delete, get & list the keys in a LWWMap. It is
+// built on a LWWSet of key & value entries identified by their key, so the latest put or delete of
+// a key wins and multiple LWWMaps are merged keeping the original timestamps
+
+// ErrEmptyKey is returned when an empty
+// key is put, deleted or looked up
+var ErrEmptyKey = errors.New("empty key provided")
+
+// Entry is a key & value
+// pair stored in a LWWMap
+type Entry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LWWMap is the LWWMap CRDT data type
+// It is implemented as a LWWSet of
+// Entries identified by their key
+// The methods changing a LWWMap have
+// pointer receivers & change it in place
+type LWWMap struct {
+	set lwwset.KeyedSet[Entry, string]
+}
+
+// entryKey is the key function
+// identifying an Entry by its key
+func entryKey(entry Entry) string {
+	return entry.Key
+}
+
+// Initialize returns a new empty LWWMap
+// configured with the given LWWSet options
+func Initialize(opts ...lwwset.Option) LWWMap {
+	return LWWMap{set: lwwset.NewKeyed(entryKey, opts...)}
+}
+
+// Put sets the value of the given key in the LWWMap
+func (lwwmap *LWWMap) Put(key string, value string) (LWWMap, error) {
+	// Return an error if the key passed is nil
+	if key == "" {
+		return *lwwmap, ErrEmptyKey
+	}
+
+	lwwmap.init()
+	_, err := lwwmap.set.Addition(Entry{Key: key, Value: value})
+	return *lwwmap, err
+}
+
+// Delete removes the given key from the LWWMap
+func (lwwmap *LWWMap) Delete(key string) (LWWMap, error) {
+	// Return an error if the key passed is nil
+	if key == "" {
+		return *lwwmap, ErrEmptyKey
+	}
+
+	lwwmap.init()
+	_, err := lwwmap.set.Removal(Entry{Key: key})
+	return *lwwmap, err
+}
+
+// Get returns the value of the given key in the
+// LWWMap along with if the key is present or not
+func (lwwmap LWWMap) Get(key string) (string, bool, error) {
+	// Return an error if the key passed is nil
+	if key == "" {
+		return "", false, ErrEmptyKey
+	}
+
+	entry, present := lwwmap.set.Get(key)
+	return entry.Value, present, nil
+}
+
+// List returns all the keys present in
+// the LWWMap along with their values
+func (lwwmap LWWMap) List() (LWWMap, map[string]string) {
+	_, entries := lwwmap.set.List()
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		values[entry.Key] = entry.Value
+	}
+
+	return lwwmap, values
+}
+
+// String returns the latest entries put & deleted
+// in the LWWMap, as printed in the logs
+func (lwwmap LWWMap) String() string {
+	return lwwmap.set.String()
+}
+
+// Compatible returns an error if the given LWWMap
+// has a different Bias and cannot be merged with
+func (lwwmap LWWMap) Compatible(other LWWMap) error {
+	return lwwmap.set.Compatible(other.set)
+}
+
+// Join merges the given LWWMaps into the LWWMap in place
+// keeping the latest put or delete of each key
+func (lwwmap *LWWMap) Join(LWWMaps ...LWWMap) LWWMap {
+	lwwmap.init()
+
+	sets := make([]lwwset.KeyedSet[Entry, string], 0, len(LWWMaps))
+	for _, other := range LWWMaps {
+		sets = append(sets, other.init().set)
+	}
+
+	lwwmap.set.Join(sets...)
+	return *lwwmap
+}
+
+// Merge conbines multiple LWWMaps together keeping the latest
+// put or delete of each key with its original timestamp
+func Merge(LWWMaps ...LWWMap) LWWMap {
+	sets := make([]lwwset.KeyedSet[Entry, string], 0, len(LWWMaps))
+	for _, lwwmap := range LWWMaps {
+		sets = append(sets, lwwmap.init().set)
+	}
+
+	return LWWMap{set: lwwset.Merge(sets...)}
+}
+
+// init sets the LWWSet of the LWWMap
+// to identify the Entries by their key
+func (lwwmap *LWWMap) init() LWWMap {
+	lwwmap.set.SetKey(entryKey)
+	return *lwwmap
+}
+
+// MarshalJSON encodes the LWWMap as its LWWSet
+// of the Entries put & deleted
+func (lwwmap LWWMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lwwmap.set)
+}
+
+// UnmarshalJSON decodes the LWWMap from its LWWSet
+// of the Entries put & deleted
+func (lwwmap *LWWMap) UnmarshalJSON(data []byte) error {
+	lwwmap.init()
+	return json.Unmarshal(data, &lwwmap.set)
+}
+
+// Clear is utility function used only for tests
+// to empty the contents of a given LWWMap
+func Clear() LWWMap {
+	return Initialize()
+}
@@ -0,0 +1,188 @@
+package lwwmap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/el10savio/lwwset-crdt/lwwset"
+)
+
+var (
+	lwwmap LWWMap
+)
+
+func init() {
+	lwwmap = Initialize()
+}
+
+// TestList checks the basic functionality of LWWMap List()
+// List() should return all the keys put with their values
+func TestList(t *testing.T) {
+	lwwmap, _ = lwwmap.Put("xx", "1")
+	lwwmap, _ = lwwmap.Put("yy", "2")
+
+	expectedValue := map[string]string{"xx": "1", "yy": "2"}
+	_, actualValue := lwwmap.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+
+	lwwmap = Clear()
+}
+
+// TestList_UpdatedValue checks the functionality of LWWMap List() when
+// a key is put multiple times it should return its latest value
+func TestList_UpdatedValue(t *testing.T) {
+	lwwmap, _ = lwwmap.Put("xx", "1")
+	lwwmap, _ = lwwmap.Put("xx", "2")
+
+	expectedValue := map[string]string{"xx": "2"}
+	_, actualValue := lwwmap.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+
+	lwwmap = Clear()
+}
+
+// TestList_Deleted checks the functionality of LWWMap List() when
+// a key is deleted it should not return the key
+func TestList_Deleted(t *testing.T) {
+	lwwmap, _ = lwwmap.Put("xx", "1")
+	lwwmap, _ = lwwmap.Put("yy", "2")
+	lwwmap, _ = lwwmap.Delete("xx")
+
+	expectedValue := map[string]string{"yy": "2"}
+	_, actualValue := lwwmap.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+
+	lwwmap = Clear()
+}
+
+// TestGet checks the basic functionality of LWWMap Get()
+// it returns the value of a key and if the key is present
+func TestGet(t *testing.T) {
+	lwwmap, _ = lwwmap.Put("xx", "1")
+
+	value, present, err := lwwmap.Get("xx")
+
+	assert.Nil(t, err)
+	assert.True(t, present)
+	assert.Equal(t, "1", value)
+
+	lwwmap = Clear()
+}
+
+// TestGet_NotPresent checks the functionality of LWWMap Get()
+// it returns false for a key deleted or never put
+func TestGet_NotPresent(t *testing.T) {
+	lwwmap, _ = lwwmap.Put("xx", "1")
+	lwwmap, _ = lwwmap.Delete("xx")
+
+	_, present, err := lwwmap.Get("xx")
+	assert.Nil(t, err)
+	assert.False(t, present)
+
+	_, present, err = lwwmap.Get("yy")
+	assert.Nil(t, err)
+	assert.False(t, present)
+
+	lwwmap = Clear()
+}
+
+// TestGet_EmptyKey checks the functionality of LWWMap Get(), Put() &
+// Delete() they return an error if the key passed is nil
+func TestGet_EmptyKey(t *testing.T) {
+	expectedError := errors.New("empty key provided")
+
+	_, _, getError := lwwmap.Get("")
+	_, putError := lwwmap.Put("", "1")
+	_, deleteError := lwwmap.Delete("")
+
+	assert.Equal(t, expectedError, getError)
+	assert.Equal(t, expectedError, putError)
+	assert.Equal(t, expectedError, deleteError)
+}
+
+// TestZeroValue checks that the zero LWWMap
+// can be used without being initialized
+func TestZeroValue(t *testing.T) {
+	var zero LWWMap
+	zero, err := zero.Put("xx", "1")
+
+	value, present, _ := zero.Get("xx")
+
+	assert.Nil(t, err)
+	assert.True(t, present)
+	assert.Equal(t, "1", value)
+}
+
+// TestMerge checks the basic functionality of LWWMap Merge() it keeps the
+// latest put or delete of each key irrespective of the order of merging
+func TestMerge(t *testing.T) {
+	clock := lwwset.NewManualClock(time.Unix(0, 10))
+	lwwmap1 := Initialize(lwwset.WithClock(clock))
+	lwwmap2 := Initialize(lwwset.WithClock(clock))
+
+	lwwmap1, _ = lwwmap1.Put("xx", "1")
+	lwwmap1, _ = lwwmap1.Put("yy", "1")
+	clock.Advance(1)
+	lwwmap2, _ = lwwmap2.Put("xx", "2")
+	lwwmap2, _ = lwwmap2.Delete("yy")
+	clock.Advance(1)
+	lwwmap1, _ = lwwmap1.Put("zz", "1")
+
+	expectedValue := map[string]string{"xx": "2", "zz": "1"}
+	_, actualValue1 := Merge(lwwmap1, lwwmap2).List()
+	_, actualValue2 := Merge(lwwmap2, lwwmap1).List()
+
+	assert.Equal(t, expectedValue, actualValue1)
+	assert.Equal(t, expectedValue, actualValue2)
+}
+
+// TestJoin checks that Join merges the LWWMaps
+// into the LWWMap in place
+func TestJoin(t *testing.T) {
+	clock := lwwset.NewManualClock(time.Unix(0, 10))
+	lwwmap1 := Initialize(lwwset.WithClock(clock))
+	lwwmap2 := Initialize(lwwset.WithClock(clock))
+
+	lwwmap1.Put("xx", "1")
+	lwwmap1.Put("yy", "1")
+	clock.Advance(1)
+	lwwmap2.Put("xx", "2")
+	lwwmap2.Delete("yy")
+
+	lwwmap1.Join(lwwmap2)
+	_, actualValue := lwwmap1.List()
+
+	assert.Equal(t, map[string]string{"xx": "2"}, actualValue)
+}
+
+// TestJSON checks that a LWWMap encoded in JSON is decoded
+// back to the same LWWMap with the original timestamps
+func TestJSON(t *testing.T) {
+	lwwmap, _ = lwwmap.Put("xx", "1")
+	lwwmap, _ = lwwmap.Put("yy", "2")
+	lwwmap, _ = lwwmap.Delete("yy")
+
+	data, err := json.Marshal(lwwmap)
+	assert.Nil(t, err)
+
+	var decoded LWWMap
+	err = json.Unmarshal(data, &decoded)
+	assert.Nil(t, err)
+
+	_, expectedValue := lwwmap.List()
+	_, actualValue := decoded.List()
+	assert.Equal(t, expectedValue, actualValue)
+
+	lwwmap, _ = lwwmap.Put("yy", "3")
+	_, actualValue = Merge(decoded, lwwmap).List()
+	assert.Equal(t, map[string]string{"xx": "1", "yy": "3"}, actualValue)
+
+	lwwmap = Clear()
+}
@@ -0,0 +1,44 @@
+package lwwset
+
+// BatchOp is an add or a remove of a
+// value applied in a batch to a LWWSet
+type BatchOp[T any] struct {
+	Type  OpType `json:"type"`
+	Value T      `json:"value"`
+}
+
+// ApplyBatch applies the adds & removes of the values to the LWWSet in
+// a single pass, timestamping every operation with the same timestamp
+// It returns the LWWSet along with the error of each operation, nil if
+// it was applied. An add & a remove of the same value in a batch have
+// the same timestamp so the Bias of the LWWSet decides which one wins
+func (lwwset *KeyedSet[T, K]) ApplyBatch(ops []BatchOp[T]) (KeyedSet[T, K], []error) {
+	lwwset.init()
+
+	errs := make([]error, len(ops))
+	timestamp := lwwset.now()
+
+	for index, op := range ops {
+		// Return an error for the operation
+		// if the value passed is nil
+		key, err := lwwset.validKey(op.Value)
+		if err != nil {
+			errs[index] = err
+			continue
+		}
+
+		node := Node[T]{Value: op.Value, Timestamp: timestamp, Replica: lwwset.store.replica}
+		switch op.Type {
+		case AddOp:
+			lwwset.addNode(key, node)
+		case RemoveOp:
+			lwwset.removeNode(key, node)
+		default:
+			errs[index] = ErrInvalidOpType
+			continue
+		}
+		lwwset.record(Op[T]{ID: newOpID(), Type: op.Type, Node: node})
+	}
+
+	return *lwwset, errs
+}
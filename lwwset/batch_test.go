@@ -0,0 +1,67 @@
+package lwwset
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApplyBatch checks that ApplyBatch applies the adds & removes of
+// a batch with the same timestamp and returns the error of each one
+func TestApplyBatch(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()), WithReplica("a"), WithOpLog(0))
+	lwwset, _ = lwwset.Addition("zz")
+
+	lwwset, errs := lwwset.ApplyBatch([]BatchOp[string]{
+		{Type: AddOp, Value: "xx"},
+		{Type: AddOp, Value: "yy"},
+		{Type: RemoveOp, Value: "zz"},
+		{Type: AddOp, Value: ""},
+		{Type: "move", Value: "xx"},
+	})
+
+	assert.Equal(t, []error{
+		nil,
+		nil,
+		nil,
+		errors.New("empty value provided"),
+		errors.New("invalid operation type provided"),
+	}, errs)
+
+	_, actualValue := lwwset.List()
+	assert.Equal(t, []string{"xx", "yy"}, actualValue)
+
+	expectedNodes := LWWNodeSlice{replicaNode("xx", 2, "a"), replicaNode("yy", 2, "a")}
+	assert.Equal(t, expectedNodes, lwwset.AddNodes())
+
+	ops, _, _ := lwwset.OpsSince(1)
+	assert.Len(t, ops, 3)
+}
+
+// TestApplyBatch_Bias checks that an add & a remove of the
+// same value in a batch are resolved by the Bias
+func TestApplyBatch_Bias(t *testing.T) {
+	for _, bias := range []Bias{AddBias, RemoveBias} {
+		lwwset := Initialize(WithClock(NewManualClock(time.Unix(0, 10))), WithBias(bias))
+
+		lwwset, _ = lwwset.ApplyBatch([]BatchOp[string]{
+			{Type: RemoveOp, Value: "xx"},
+			{Type: AddOp, Value: "xx"},
+		})
+
+		present, _ := lwwset.Lookup("xx")
+		assert.Equal(t, bias == AddBias, present, bias)
+	}
+}
+
+// TestApplyBatch_Empty checks that an empty
+// batch does not change the LWWSet
+func TestApplyBatch_Empty(t *testing.T) {
+	lwwset := Initialize()
+	lwwset, errs := lwwset.ApplyBatch(nil)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, uint64(0), lwwset.Version())
+}
@@ -0,0 +1,52 @@
+package lwwset
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Bias decides if a value added & removed
+// with the same timestamp is present or not
+type Bias string
+
+const (
+	// AddBias keeps a value present when it is
+	// added & removed with the same timestamp
+	AddBias Bias = "add"
+
+	// RemoveBias keeps a value absent when it is added
+	// & removed with the same timestamp, it is the default
+	RemoveBias Bias = "remove"
+)
+
+// ErrBiasMismatch is returned when LWWSets
+// with different biases are to be merged
+var ErrBiasMismatch = errors.New("lwwset bias mismatch")
+
+// normalize returns the Bias with the
+// empty Bias resolved to RemoveBias
+func (bias Bias) normalize() Bias {
+	if bias == "" {
+		return RemoveBias
+	}
+	return bias
+}
+
+// Compatible returns an error if the given LWWSet
+// has a different Bias and cannot be merged with
+func (lwwset KeyedSet[T, K]) Compatible(other KeyedSet[T, K]) error {
+	if lwwset.Bias().normalize() != other.Bias().normalize() {
+		return fmt.Errorf("%w: %s and %s", ErrBiasMismatch, lwwset.Bias().normalize(), other.Bias().normalize())
+	}
+	return nil
+}
+
+// addWins reports if the add Node supersedes the remove Node
+// by comparing their timestamps only, using the Bias when they
+// have the same timestamp whichever replicas they were made on
+func addWins[T any](bias Bias, add Node[T], remove Node[T]) bool {
+	if add.Timestamp.UnixNano() != remove.Timestamp.UnixNano() {
+		return add.Timestamp.UnixNano() > remove.Timestamp.UnixNano()
+	}
+	return bias.normalize() == AddBias
+}
@@ -0,0 +1,129 @@
+package lwwset
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBias_Default checks that a LWWSet is initialized with the
+// RemoveBias so a value added & removed at the same time is absent
+func TestBias_Default(t *testing.T) {
+	lwwset := Initialize(WithClock(NewManualClock(time.Unix(0, 10))))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("xx")
+
+	present, _ := lwwset.Lookup("xx")
+
+	assert.Equal(t, RemoveBias, lwwset.Bias())
+	assert.False(t, present)
+}
+
+// TestBias_Add checks that a LWWSet initialized with the AddBias
+// keeps a value added & removed at the same time present
+func TestBias_Add(t *testing.T) {
+	lwwset := Initialize(WithClock(NewManualClock(time.Unix(0, 10))), WithBias(AddBias))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("xx")
+
+	present, _ := lwwset.Lookup("xx")
+
+	assert.True(t, present)
+}
+
+// TestBias_AddLaterRemove checks that a LWWSet initialized with the
+// AddBias still removes a value removed after it was added
+func TestBias_AddLaterRemove(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock), WithBias(AddBias))
+
+	lwwset, _ = lwwset.Addition("xx")
+	clock.Advance(1)
+	lwwset, _ = lwwset.Removal("xx")
+
+	present, _ := lwwset.Lookup("xx")
+
+	assert.False(t, present)
+}
+
+// TestBias_JSON checks that the Bias of
+// a LWWSet is carried in its JSON encoding
+func TestBias_JSON(t *testing.T) {
+	data, err := json.Marshal(Initialize(WithBias(AddBias)))
+	assert.Nil(t, err)
+
+	var lwwset LWWSet
+	err = json.Unmarshal(data, &lwwset)
+
+	assert.Nil(t, err)
+	assert.Equal(t, AddBias, lwwset.Bias())
+}
+
+// TestCompatible checks that LWWSets with the same Bias are compatible
+// and a LWWSet without a Bias is treated as having the RemoveBias
+func TestCompatible(t *testing.T) {
+	assert.Nil(t, Initialize(WithBias(AddBias)).Compatible(Initialize(WithBias(AddBias))))
+	assert.Nil(t, Initialize().Compatible(LWWSet{}))
+}
+
+// TestCompatible_Mismatch checks that LWWSets with
+// different biases are not compatible
+func TestCompatible_Mismatch(t *testing.T) {
+	err := Initialize(WithBias(AddBias)).Compatible(Initialize(WithBias(RemoveBias)))
+
+	assert.True(t, errors.Is(err, ErrBiasMismatch))
+}
+
+// TestMerge_Bias checks that the LWWSet returned
+// by Merge keeps the Bias of the first LWWSet
+func TestMerge_Bias(t *testing.T) {
+	lwwset := Initialize(WithBias(AddBias))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("xx", node("xx", 10))
+
+	merged := Merge(lwwset, Initialize(WithBias(AddBias)))
+	present, _ := merged.Lookup("xx")
+
+	assert.Equal(t, AddBias, merged.Bias())
+	assert.True(t, present)
+}
+
+// TestBias_Replicas checks that a value added & removed with the
+// same timestamp on different replicas is resolved by the Bias,
+// whichever replica the addition & the removal were made on
+func TestBias_Replicas(t *testing.T) {
+	tests := []struct {
+		bias          Bias
+		addReplica    string
+		removeReplica string
+		present       bool
+	}{
+		{RemoveBias, "a", "b", false},
+		{RemoveBias, "b", "a", false},
+		{AddBias, "a", "b", true},
+		{AddBias, "b", "a", true},
+	}
+
+	for _, test := range tests {
+		added := Initialize(WithBias(test.bias), WithHistory(0))
+		added.addNode("xx", replicaNode("xx", 10, test.addReplica))
+
+		removed := Initialize(WithBias(test.bias), WithHistory(0))
+		removed.removeNode("xx", replicaNode("xx", 10, test.removeReplica))
+
+		addedClone, removedClone := added.Clone(), removed.Clone()
+		for _, merged := range []LWWSet{addedClone.Join(removed), removedClone.Join(added)} {
+			present, _ := merged.Lookup("xx")
+			assert.Equal(t, test.present, present, test)
+
+			present, err := merged.LookupAt("xx", time.Unix(0, 10))
+			assert.Nil(t, err, test)
+			assert.Equal(t, test.present, present, test)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package lwwset
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of the timestamps given to the
+// LWWNodes when values are added to or removed from a LWWSet
+type Clock interface {
+	Now() time.Time
+}
+
+// WallClock is the Clock backed by the system
+// time, it is used when no Clock is provided
+type WallClock struct{}
+
+// Now returns the current system time
+func (WallClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a Clock which only moves when it is
+// set or advanced, used to write deterministic tests
+type ManualClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// NewManualClock returns a new ManualClock
+// starting at the given time
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time the ManualClock is set to
+func (clock *ManualClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+// Set moves the ManualClock to the given time
+func (clock *ManualClock) Set(now time.Time) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = now
+}
+
+// Advance moves the ManualClock forward by the given duration
+func (clock *ManualClock) Advance(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = clock.now.Add(duration)
+}
+
+// CounterClock is a monotonic Clock that returns a timestamp
+// one nanosecond after the previous one on every call
+type CounterClock struct {
+	counter int64
+}
+
+// NewCounterClock returns a new CounterClock
+// starting from the Unix epoch
+func NewCounterClock() *CounterClock {
+	return &CounterClock{}
+}
+
+// Now returns the next timestamp of the CounterClock
+func (clock *CounterClock) Now() time.Time {
+	return time.Unix(0, atomic.AddInt64(&clock.counter, 1))
+}
+
+// Reader is implemented by Clocks which issue a new timestamp on
+// Now, so that their time can be read without changing them
+type Reader interface {
+	Read() time.Time
+}
+
+// Read returns the latest timestamp of
+// the CounterClock without moving it
+func (clock *CounterClock) Read() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&clock.counter))
+}
+
+// Observer is implemented by Clocks that need to move
+// past the timestamps seen from other replicas on Merge
+type Observer interface {
+	Observe(time.Time)
+}
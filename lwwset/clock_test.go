@@ -0,0 +1,116 @@
+package lwwset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestManualClock checks the basic functionality of the ManualClock
+// it only moves when it is explicitly set or advanced
+func TestManualClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+
+	assert.Equal(t, time.Unix(0, 10), clock.Now())
+	assert.Equal(t, time.Unix(0, 10), clock.Now())
+
+	clock.Advance(5)
+	assert.Equal(t, time.Unix(0, 15), clock.Now())
+
+	clock.Set(time.Unix(0, 100))
+	assert.Equal(t, time.Unix(0, 100), clock.Now())
+}
+
+// TestCounterClock checks the basic functionality of the CounterClock
+// it returns a strictly increasing timestamp on every call
+func TestCounterClock(t *testing.T) {
+	clock := NewCounterClock()
+
+	assert.Equal(t, time.Unix(0, 1), clock.Now())
+	assert.Equal(t, time.Unix(0, 2), clock.Now())
+	assert.Equal(t, time.Unix(0, 3), clock.Now())
+}
+
+// TestCounterClock_Read checks that the values of a LWWSet are
+// read without moving its CounterClock, even when they expire
+func TestCounterClock_Read(t *testing.T) {
+	clock := NewCounterClock()
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.AdditionWithTTL("xx", 10)
+	assert.Equal(t, time.Unix(0, 1), clock.Read())
+
+	for i := 0; i < 20; i++ {
+		present, _ := lwwset.Lookup("xx")
+		assert.True(t, present)
+		lwwset, _ = lwwset.List()
+	}
+	assert.Equal(t, time.Unix(0, 1), clock.Read())
+}
+
+// TestWithClock checks that a LWWSet initialized with
+// a Clock timestamps its values using that Clock
+func TestWithClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.Addition("xx")
+	clock.Advance(10)
+	lwwset, _ = lwwset.Removal("yy")
+
+	assert.Equal(t, LWWNodeSlice{node("xx", 10)}, lwwset.AddNodes())
+	assert.Equal(t, LWWNodeSlice{node("yy", 20)}, lwwset.RemoveNodes())
+}
+
+// TestWithClock_RemoveAfterAdd checks that a value removed
+// after it was added is not present in the LWWSet
+func TestWithClock_RemoveAfterAdd(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.Addition("xx")
+	clock.Advance(1)
+	lwwset, _ = lwwset.Removal("xx")
+
+	present, _ := lwwset.Lookup("xx")
+	assert.False(t, present)
+}
+
+// TestWithClock_AddAfterRemove checks that a value added
+// after it was removed is present in the LWWSet
+func TestWithClock_AddAfterRemove(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.Removal("xx")
+	clock.Advance(1)
+	lwwset, _ = lwwset.Addition("xx")
+
+	present, _ := lwwset.Lookup("xx")
+	assert.True(t, present)
+}
+
+// TestWithClock_SameTimestamp checks that a value added & removed
+// at the same timestamp is not present in the LWWSet
+func TestWithClock_SameTimestamp(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("xx")
+
+	present, _ := lwwset.Lookup("xx")
+	assert.False(t, present)
+}
+
+// TestWithClock_Merge checks that the LWWSet returned
+// by Merge keeps using the Clock of the first LWWSet
+func TestWithClock_Merge(t *testing.T) {
+	clock := NewCounterClock()
+	lwwset := Merge(Initialize(WithClock(clock)), Initialize())
+
+	lwwset, _ = lwwset.Addition("xx")
+
+	assert.Equal(t, LWWNodeSlice{node("xx", 1)}, lwwset.AddNodes())
+}
@@ -0,0 +1,41 @@
+package lwwset
+
+// Version returns the version of the LWWSet which
+// is incremented on every change made to its values
+// Versions are local to a LWWSet and are used to
+// request the changes made since a given version
+func (lwwset KeyedSet[T, K]) Version() uint64 {
+	if lwwset.store == nil {
+		return 0
+	}
+	return lwwset.store.version
+}
+
+// DeltaSince returns a LWWSet holding only the values changed after
+// the given version. Joining the delta into a LWWSet which already
+// joined the LWWSet at that version brings it up to date
+func (lwwset KeyedSet[T, K]) DeltaSince(version uint64) KeyedSet[T, K] {
+	delta := NewKeyed[T, K](nil)
+	if lwwset.store == nil {
+		return delta
+	}
+
+	delta.store.bias = lwwset.store.bias
+	delta.store.key = lwwset.store.key
+
+	for _, key := range lwwset.store.keys {
+		lwwentry := lwwset.store.entries[key]
+		if lwwentry.version <= version {
+			continue
+		}
+
+		if lwwentry.add != nil {
+			delta.addNode(key, *lwwentry.add)
+		}
+		if lwwentry.remove != nil {
+			delta.removeNode(key, *lwwentry.remove)
+		}
+	}
+
+	return delta
+}
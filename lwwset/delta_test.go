@@ -0,0 +1,83 @@
+package lwwset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersion checks that the version of a LWWSet
+// is incremented on every change made to its values
+func TestVersion(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+	assert.Equal(t, uint64(0), lwwset.Version())
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Addition("yy")
+	lwwset, _ = lwwset.Removal("xx")
+
+	assert.Equal(t, uint64(3), lwwset.Version())
+}
+
+// TestVersion_NoChange checks that the version of a LWWSet
+// is not incremented when a join does not change its values
+func TestVersion_NoChange(t *testing.T) {
+	lwwset := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("yy", 20)})
+	version := lwwset.Version()
+
+	lwwset = lwwset.Join(fromNodes(LWWNodeSlice{node("xx", 5), node("yy", 15)}, LWWNodeSlice{}))
+
+	assert.Equal(t, version, lwwset.Version())
+}
+
+// TestDeltaSince checks that DeltaSince returns only
+// the values changed after the given version
+func TestDeltaSince(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Addition("yy")
+	version := lwwset.Version()
+	lwwset, _ = lwwset.Addition("zz")
+	lwwset, _ = lwwset.Removal("xx")
+
+	delta := lwwset.DeltaSince(version)
+
+	assert.Equal(t, LWWNodeSlice{node("zz", 3)}, delta.AddNodes())
+	assert.Equal(t, LWWNodeSlice{node("xx", 4)}, delta.RemoveNodes())
+	assert.Equal(t, lwwset.Bias(), delta.Bias())
+}
+
+// TestDeltaSince_Zero checks that DeltaSince
+// the version 0 returns the entire LWWSet
+func TestDeltaSince_Zero(t *testing.T) {
+	lwwset := fromNodes(LWWNodeSlice{node("xx", 10), node("yy", 10)}, LWWNodeSlice{node("yy", 20)})
+
+	delta := lwwset.DeltaSince(0)
+
+	assert.Equal(t, lwwset.AddNodes(), delta.AddNodes())
+	assert.Equal(t, lwwset.RemoveNodes(), delta.RemoveNodes())
+}
+
+// TestDeltaSince_Join checks that joining the deltas of a LWWSet
+// into a replica brings the replica up to date with the LWWSet
+func TestDeltaSince_Join(t *testing.T) {
+	clock := NewCounterClock()
+	lwwset := Initialize(WithClock(clock))
+	replica := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Addition("yy")
+	replica = replica.Join(lwwset.DeltaSince(0))
+	version := lwwset.Version()
+
+	lwwset, _ = lwwset.Removal("xx")
+	lwwset, _ = lwwset.Addition("zz")
+	replica = replica.Join(lwwset.DeltaSince(version))
+
+	_, expectedValue := lwwset.List()
+	_, actualValue := replica.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+	assert.Equal(t, lwwset.RemoveNodes(), replica.RemoveNodes())
+}
@@ -0,0 +1,143 @@
+package lwwset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// setType identifies the JSON encoding of a LWWSet so
+// that it is not mistaken for another set type's encoding
+const setType = "lwwset"
+
+// ErrTypeMismatch is returned when decoding
+// the JSON encoding of another set type
+var ErrTypeMismatch = errors.New("encoding of another set type provided")
+
+// jsonNode has the fields of a Node without its JSON
+// methods to encode the fields other than the timestamps
+type jsonNode[T any] Node[T]
+
+// MarshalJSON encodes the Node with its timestamp & expiry
+// as integers of nanoseconds, the expiry is omitted if not set
+func (lwwnode Node[T]) MarshalJSON() ([]byte, error) {
+	var expiry int64
+	if !lwwnode.Expiry.IsZero() {
+		expiry = lwwnode.Expiry.UnixNano()
+	}
+
+	return json.Marshal(struct {
+		jsonNode[T]
+		Timestamp int64
+		Expiry    int64 `json:",omitempty"`
+	}{
+		jsonNode:  jsonNode[T](lwwnode),
+		Timestamp: lwwnode.Timestamp.UnixNano(),
+		Expiry:    expiry,
+	})
+}
+
+// UnmarshalJSON decodes the Node with its timestamp either as an
+// integer of nanoseconds or as a RFC 3339 string sent by older nodes
+func (lwwnode *Node[T]) UnmarshalJSON(data []byte) error {
+	decoded := struct {
+		*jsonNode[T]
+		Timestamp json.RawMessage
+		Expiry    int64
+	}{
+		jsonNode: (*jsonNode[T])(lwwnode),
+	}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	lwwnode.Expiry = time.Time{}
+	if decoded.Expiry != 0 {
+		lwwnode.Expiry = time.Unix(0, decoded.Expiry)
+	}
+
+	if len(decoded.Timestamp) == 0 || bytes.Equal(decoded.Timestamp, []byte("null")) {
+		lwwnode.Timestamp = time.Time{}
+		return nil
+	}
+
+	if decoded.Timestamp[0] == '"' {
+		return json.Unmarshal(decoded.Timestamp, &lwwnode.Timestamp)
+	}
+
+	var nanoseconds int64
+	err = json.Unmarshal(decoded.Timestamp, &nanoseconds)
+	if err != nil {
+		return err
+	}
+
+	lwwnode.Timestamp = time.Unix(0, nanoseconds)
+	return nil
+}
+
+// jsonSet is the JSON encoding of a
+// LWWSet with its added & removed Nodes
+type jsonSet[T any] struct {
+	Type   string       `json:"type"`
+	Add    NodeSlice[T] `json:"add"`
+	Remove NodeSlice[T] `json:"remove"`
+	Bias   Bias         `json:"bias"`
+}
+
+// MarshalJSON encodes the LWWSet with the latest
+// Nodes of the values added & removed
+func (lwwset KeyedSet[T, K]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSet[T]{
+		Type:   setType,
+		Add:    lwwset.AddNodes(),
+		Remove: lwwset.RemoveNodes(),
+		Bias:   lwwset.Bias(),
+	})
+}
+
+// UnmarshalJSON decodes the LWWSet from its added & removed Nodes
+// The Clock, replica & key function of the LWWSet are kept, it
+// returns ErrNoKey if the values are not keys & no key function
+// was set with SetKey. The encoding of another set type is refused
+// while the encoding without a type sent by older nodes is decoded
+func (lwwset *KeyedSet[T, K]) UnmarshalJSON(data []byte) error {
+	var decoded jsonSet[T]
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Type != "" && decoded.Type != setType {
+		return fmt.Errorf("%w: %s", ErrTypeMismatch, decoded.Type)
+	}
+
+	set := NewKeyed[T, K](nil)
+	if lwwset.store != nil {
+		set.store.clock = lwwset.store.clock
+		set.store.replica = lwwset.store.replica
+		set.store.key = lwwset.store.key
+	}
+	set.store.bias = decoded.Bias
+
+	for _, node := range decoded.Add {
+		key, err := set.keyOf(node.Value)
+		if err != nil {
+			return err
+		}
+		set.addNode(key, node)
+	}
+	for _, node := range decoded.Remove {
+		key, err := set.keyOf(node.Value)
+		if err != nil {
+			return err
+		}
+		set.removeNode(key, node)
+	}
+
+	*lwwset = set
+	return nil
+}
@@ -0,0 +1,101 @@
+package lwwset
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLWWNode_MarshalJSON checks that a LWWNode is encoded
+// in JSON with its timestamp as an integer of nanoseconds
+func TestLWWNode_MarshalJSON(t *testing.T) {
+	expectedValue := `{"Value":"xx","Timestamp":1600000000000000001}`
+	actualValue, err := json.Marshal(node("xx", 1600000000000000001))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, string(actualValue))
+}
+
+// TestLWWNode_UnmarshalJSON checks that a LWWNode encoded
+// in JSON is decoded back with the same timestamp
+func TestLWWNode_UnmarshalJSON(t *testing.T) {
+	var actualValue LWWNode
+	err := json.Unmarshal([]byte(`{"Value":"xx","Timestamp":1600000000000000001}`), &actualValue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, node("xx", 1600000000000000001), actualValue)
+}
+
+// TestLWWNode_UnmarshalJSON_RFC3339 checks that a LWWNode with its
+// timestamp encoded as a RFC 3339 string by older nodes is decoded
+func TestLWWNode_UnmarshalJSON_RFC3339(t *testing.T) {
+	var actualValue LWWNode
+	err := json.Unmarshal([]byte(`{"Value":"xx","Timestamp":"2020-09-13T12:26:40.000000001Z"}`), &actualValue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "xx", actualValue.Value)
+	assert.Equal(t, int64(1600000000000000001), actualValue.Timestamp.UnixNano())
+}
+
+// TestLWWSet_MarshalJSON checks that a LWWSet is encoded in
+// JSON with the latest LWWNodes of its values & its Bias
+func TestLWWSet_MarshalJSON(t *testing.T) {
+	lwwset := fromNodes(LWWNodeSlice{node("xx", 10), node("yy", 10)}, LWWNodeSlice{node("yy", 20)})
+
+	expectedValue := `{"type":"lwwset","add":[{"Value":"xx","Timestamp":10}],"remove":[{"Value":"yy","Timestamp":20}],"bias":"remove"}`
+	actualValue, err := json.Marshal(lwwset)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, string(actualValue))
+}
+
+// TestLWWSet_JSON checks that a LWWSet encoded in
+// JSON is decoded back to the same LWWSet
+func TestLWWSet_JSON(t *testing.T) {
+	expectedValue := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("yy", 20)})
+
+	data, err := json.Marshal(expectedValue)
+	assert.Nil(t, err)
+
+	var actualValue LWWSet
+	err = json.Unmarshal(data, &actualValue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue.AddNodes(), actualValue.AddNodes())
+	assert.Equal(t, expectedValue.RemoveNodes(), actualValue.RemoveNodes())
+	assert.Equal(t, time.Unix(0, 20), actualValue.RemoveNodes()[0].Timestamp)
+}
+
+// TestLWWSet_UnmarshalJSON_Type checks that the encoding of a LWWSet
+// without a type sent by older nodes is decoded and that the
+// encoding of another set type is refused
+func TestLWWSet_UnmarshalJSON_Type(t *testing.T) {
+	var lwwset LWWSet
+
+	err := json.Unmarshal([]byte(`{"add":[{"Value":"xx","Timestamp":10}],"remove":[]}`), &lwwset)
+	present, _ := lwwset.Lookup("xx")
+	assert.Nil(t, err)
+	assert.True(t, present)
+
+	err = json.Unmarshal([]byte(`{"type":"orset","entries":[]}`), &lwwset)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+}
+
+// TestLWWNode_JSON_Expiry checks that the expiry of a LWWNode is encoded
+// in JSON as an integer of nanoseconds and decoded back
+func TestLWWNode_JSON_Expiry(t *testing.T) {
+	expectedValue := LWWNode{Value: "xx", Timestamp: time.Unix(0, 10), Expiry: time.Unix(0, 20)}
+
+	data, err := json.Marshal(expectedValue)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Value":"xx","Timestamp":10,"Expiry":20}`, string(data))
+
+	var actualValue LWWNode
+	err = json.Unmarshal(data, &actualValue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+}
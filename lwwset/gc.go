@@ -0,0 +1,51 @@
+package lwwset
+
+import "time"
+
+// PruneTombstones drops the values removed from the LWWSet whose
+// removal is at or before the given version, returning the number of
+// values dropped. It is to be called with a version every replica has
+// joined, once no replica can send back an older addition of the value
+func (lwwset *KeyedSet[T, K]) PruneTombstones(version uint64) int {
+	return lwwset.prune(func(lwwentry *entry[T]) bool {
+		return lwwentry.version <= version
+	})
+}
+
+// PruneTombstonesBefore drops the values removed from the LWWSet
+// with a removal timestamp before the given time, returning the number
+// of values dropped. It is a fallback to PruneTombstones assuming
+// every replica has joined the removals older than the given time
+func (lwwset *KeyedSet[T, K]) PruneTombstonesBefore(timestamp time.Time) int {
+	return lwwset.prune(func(lwwentry *entry[T]) bool {
+		return lwwentry.remove.Timestamp.Before(timestamp)
+	})
+}
+
+// prune drops the entries of the values removed which are
+// stable according to the given function along with their
+// Records in the history if enabled
+func (lwwset KeyedSet[T, K]) prune(stable func(*entry[T]) bool) int {
+	if lwwset.store == nil {
+		return 0
+	}
+
+	keys := make([]K, 0, len(lwwset.store.keys))
+	pruned := map[K]struct{}{}
+	for _, key := range lwwset.store.keys {
+		lwwentry := lwwset.store.entries[key]
+		if lwwentry.add == nil && lwwentry.remove != nil && stable(lwwentry) {
+			delete(lwwset.store.entries, key)
+			pruned[key] = struct{}{}
+			continue
+		}
+		keys = append(keys, key)
+	}
+	lwwset.store.keys = keys
+
+	if lwwset.store.history != nil && len(pruned) > 0 {
+		lwwset.store.history.forget(pruned)
+	}
+
+	return len(pruned)
+}
@@ -0,0 +1,56 @@
+package lwwset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPruneTombstones checks that PruneTombstones drops only the
+// values removed at or before the given version of the LWWSet
+func TestPruneTombstones(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("xx")
+	lwwset, _ = lwwset.Removal("yy")
+	version := lwwset.Version()
+	lwwset, _ = lwwset.Removal("zz")
+	lwwset, _ = lwwset.Addition("aa")
+
+	pruned := lwwset.PruneTombstones(version)
+	_, list := lwwset.List()
+
+	assert.Equal(t, 2, pruned)
+	assert.Equal(t, LWWNodeSlice{node("zz", 4)}, lwwset.RemoveNodes())
+	assert.Equal(t, []string{"aa"}, list)
+}
+
+// TestPruneTombstones_ReAdd checks that a value can
+// be added back after its removal was pruned
+func TestPruneTombstones_ReAdd(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("xx")
+	lwwset.PruneTombstones(lwwset.Version())
+	lwwset, _ = lwwset.Addition("xx")
+
+	present, _ := lwwset.Lookup("xx")
+
+	assert.True(t, present)
+	assert.Equal(t, LWWNodeSlice{}, lwwset.RemoveNodes())
+}
+
+// TestPruneTombstonesBefore checks that PruneTombstonesBefore drops
+// only the values removed before the given timestamp
+func TestPruneTombstonesBefore(t *testing.T) {
+	lwwset := fromNodes(LWWNodeSlice{node("xx", 30)}, LWWNodeSlice{node("xx", 10), node("yy", 10), node("zz", 20)})
+
+	pruned := lwwset.PruneTombstonesBefore(time.Unix(0, 20))
+
+	assert.Equal(t, 1, pruned)
+	assert.Equal(t, LWWNodeSlice{node("zz", 20)}, lwwset.RemoveNodes())
+	assert.Equal(t, LWWNodeSlice{node("xx", 30)}, lwwset.AddNodes())
+}
@@ -0,0 +1,159 @@
+package lwwset
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// user is a test value which is not comparable
+// and is identified in a KeyedSet by its ID
+type user struct {
+	ID   string
+	Tags []string
+}
+
+// userID is the key function for the user test values
+func userID(value user) string {
+	return value.ID
+}
+
+// TestSet checks the basic functionality of
+// a Set of comparable values other than strings
+func TestSet(t *testing.T) {
+	set := New[int](WithClock(NewCounterClock()))
+
+	set, _ = set.Addition(1)
+	set, _ = set.Addition(2)
+	set, _ = set.Addition(0)
+	set, _ = set.Removal(2)
+
+	_, actualValue := set.List()
+	present, err := set.Lookup(0)
+
+	assert.Equal(t, []int{1, 0}, actualValue)
+	assert.Nil(t, err)
+	assert.True(t, present)
+}
+
+// TestSet_Merge checks that Merge combines
+// Sets of comparable values other than strings
+func TestSet_Merge(t *testing.T) {
+	clock := NewCounterClock()
+	set1 := New[int](WithClock(clock))
+	set2 := New[int](WithClock(clock))
+
+	set1, _ = set1.Addition(1)
+	set2, _ = set2.Addition(2)
+	set2, _ = set2.Removal(1)
+
+	_, actualValue := Merge(set1, set2).List()
+
+	assert.Equal(t, []int{2}, actualValue)
+}
+
+// TestKeyedSet checks the basic functionality of a KeyedSet
+// of values which are not comparable identified by a key
+func TestKeyedSet(t *testing.T) {
+	set := NewKeyed(userID, WithClock(NewCounterClock()))
+
+	set, _ = set.Addition(user{ID: "xx", Tags: []string{"a"}})
+	set, _ = set.Addition(user{ID: "yy"})
+	set, _ = set.Removal(user{ID: "yy"})
+
+	_, actualValue := set.List()
+	present, err := set.Lookup(user{ID: "xx"})
+
+	assert.Equal(t, []user{{ID: "xx", Tags: []string{"a"}}}, actualValue)
+	assert.Nil(t, err)
+	assert.True(t, present)
+}
+
+// TestKeyedSet_ReAdd checks that adding a value with a key already present
+// in a KeyedSet replaces the value instead of adding another one
+func TestKeyedSet_ReAdd(t *testing.T) {
+	set := NewKeyed(userID, WithClock(NewCounterClock()))
+
+	set, _ = set.Addition(user{ID: "xx", Tags: []string{"a"}})
+	set, _ = set.Addition(user{ID: "xx", Tags: []string{"b"}})
+
+	_, actualValue := set.List()
+
+	assert.Equal(t, []user{{ID: "xx", Tags: []string{"b"}}}, actualValue)
+}
+
+// TestKeyedSet_Merge checks that Merge keeps the latest value for
+// each key of the KeyedSets irrespective of the order of merging
+func TestKeyedSet_Merge(t *testing.T) {
+	set1 := NewKeyed(userID)
+	set1.addNode("xx", Node[user]{Value: user{ID: "xx", Tags: []string{"a"}}, Timestamp: time.Unix(0, 20)})
+	set2 := NewKeyed(userID)
+	set2.addNode("xx", Node[user]{Value: user{ID: "xx", Tags: []string{"b"}}, Timestamp: time.Unix(0, 10)})
+
+	expectedValue := []user{{ID: "xx", Tags: []string{"a"}}}
+	_, actualValue1 := Merge(set1, set2).List()
+	_, actualValue2 := Merge(set2, set1).List()
+
+	assert.Equal(t, expectedValue, actualValue1)
+	assert.Equal(t, expectedValue, actualValue2)
+}
+
+// TestKeyedSet_EmptyKey checks that a value with an empty
+// string key cannot be added to or removed from a KeyedSet
+func TestKeyedSet_EmptyKey(t *testing.T) {
+	expectedError := errors.New("empty value provided")
+	set := NewKeyed(userID)
+
+	_, additionError := set.Addition(user{Tags: []string{"a"}})
+	_, removalError := set.Removal(user{})
+
+	assert.Equal(t, expectedError, additionError)
+	assert.Equal(t, expectedError, removalError)
+}
+
+// TestKeyedSet_Get checks that Get returns the value present in a
+// KeyedSet for a given key and nothing for a value removed or absent
+func TestKeyedSet_Get(t *testing.T) {
+	set := NewKeyed(userID, WithClock(NewCounterClock()))
+
+	set, _ = set.Addition(user{ID: "xx", Tags: []string{"a"}})
+	set, _ = set.Addition(user{ID: "yy"})
+	set, _ = set.Removal(user{ID: "yy"})
+
+	value, present := set.Get("xx")
+	assert.True(t, present)
+	assert.Equal(t, user{ID: "xx", Tags: []string{"a"}}, value)
+
+	_, present = set.Get("yy")
+	assert.False(t, present)
+
+	_, present = set.Get("zz")
+	assert.False(t, present)
+}
+
+// TestKeyedSet_NoKey checks that a KeyedSet of values which are not
+// keys returns ErrNoKey instead of panicking without a key function
+func TestKeyedSet_NoKey(t *testing.T) {
+	var set KeyedSet[user, string]
+
+	_, additionError := set.Addition(user{ID: "xx"})
+	_, removalError := set.Removal(user{ID: "xx"})
+	_, lookupError := set.Lookup(user{ID: "xx"})
+
+	assert.True(t, errors.Is(additionError, ErrNoKey))
+	assert.True(t, errors.Is(removalError, ErrNoKey))
+	assert.True(t, errors.Is(lookupError, ErrNoKey))
+
+	data := []byte(`{"add":[{"Value":{"ID":"xx"},"Timestamp":10}],"remove":[]}`)
+	err := json.Unmarshal(data, &KeyedSet[user, string]{})
+	assert.True(t, errors.Is(err, ErrNoKey))
+
+	decoded := NewKeyed(userID)
+	err = json.Unmarshal(data, &decoded)
+	present, _ := decoded.Lookup(user{ID: "xx"})
+	assert.Nil(t, err)
+	assert.True(t, present)
+}
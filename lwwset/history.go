@@ -0,0 +1,259 @@
+package lwwset
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// DefaultHistoryLimit is the number of Records
+// kept for each value if no limit is given
+const DefaultHistoryLimit = 100
+
+// ErrHistoryDisabled is returned when querying the
+// history of a LWWSet initialized without WithHistory
+var ErrHistoryDisabled = errors.New("history not enabled")
+
+// ErrHistoryTruncated is returned when querying a LWWSet at a
+// time before the earliest Records retained in its history
+var ErrHistoryTruncated = errors.New("history truncated before the given time")
+
+// Record is an operation made on a value of a LWWSet
+// along with the Node holding its timestamp & replica
+type Record[T any] struct {
+	Type OpType  `json:"type"`
+	Node Node[T] `json:"node"`
+}
+
+// history holds for each value the Records of the operations
+// observed by a LWWSet ordered by their Nodes, keeping only
+// the latest limit Records of each value. The Records of a
+// value are dropped once its removal is garbage collected
+type history[T any, K comparable] struct {
+	values map[K]*valueHistory[T]
+	// keys are the keys of the values
+	// in the order they were first seen
+	keys  []K
+	limit int
+	// pruned is the latest timestamp of the Records
+	// dropped with the values garbage collected
+	pruned time.Time
+}
+
+// valueHistory holds the Records of a value and
+// if earlier Records were dropped past the limit
+type valueHistory[T any] struct {
+	records   []Record[T]
+	truncated bool
+}
+
+// WithHistory records the operations made on & merged into the
+// LWWSet for each value, keeping the latest limit Records of each
+// value, or DefaultHistoryLimit Records if limit is not positive
+func WithHistory(limit int) Option {
+	return func(options *options) {
+		if limit <= 0 {
+			limit = DefaultHistoryLimit
+		}
+		options.historyLimit = limit
+	}
+}
+
+// clone returns a copy of the history
+func (lwwhistory *history[T, K]) clone() *history[T, K] {
+	values := make(map[K]*valueHistory[T], len(lwwhistory.values))
+	for key, records := range lwwhistory.values {
+		values[key] = &valueHistory[T]{records: append([]Record[T]{}, records.records...), truncated: records.truncated}
+	}
+	return &history[T, K]{values: values, keys: append([]K{}, lwwhistory.keys...), limit: lwwhistory.limit, pruned: lwwhistory.pruned}
+}
+
+// forget drops the Records of the values of the given keys
+// whose removals were garbage collected, queries before
+// their latest Records are then truncated
+func (lwwhistory *history[T, K]) forget(keys map[K]struct{}) {
+	for key := range keys {
+		values, ok := lwwhistory.values[key]
+		if !ok {
+			continue
+		}
+		if records := values.records; len(records) > 0 && records[len(records)-1].Node.Timestamp.After(lwwhistory.pruned) {
+			lwwhistory.pruned = records[len(records)-1].Node.Timestamp
+		}
+		delete(lwwhistory.values, key)
+	}
+
+	retained := make([]K, 0, len(lwwhistory.values))
+	for _, key := range lwwhistory.keys {
+		if _, ok := lwwhistory.values[key]; ok {
+			retained = append(retained, key)
+		}
+	}
+	lwwhistory.keys = retained
+}
+
+// remember adds the Record of the operation on the
+// Node to the history of its value if enabled
+func (lwwset KeyedSet[T, K]) remember(opType OpType, key K, node Node[T]) {
+	history := lwwset.store.history
+	if history == nil {
+		return
+	}
+
+	record := Record[T]{Type: opType, Node: node}
+	values, ok := history.values[key]
+	if !ok {
+		values = &valueHistory[T]{}
+		history.values[key] = values
+		history.keys = append(history.keys, key)
+	}
+	records := values.records
+
+	// Find the position of the Record in the
+	// ordered Records, ignoring it if already
+	// present as Nodes are merged repeatedly
+	index := sort.Search(len(records), func(i int) bool {
+		return !recordBefore(records[i], record)
+	})
+	if index < len(records) && !recordBefore(record, records[index]) {
+		return
+	}
+
+	// A Record older than every Record retained is not
+	// added back once the earlier Records were dropped
+	if index == 0 && len(records) >= history.limit {
+		values.truncated = true
+		return
+	}
+
+	records = append(records, Record[T]{})
+	copy(records[index+1:], records[index:])
+	records[index] = record
+
+	if len(records) > history.limit {
+		records = records[len(records)-history.limit:]
+		values.truncated = true
+	}
+	values.records = records
+}
+
+// recordBefore reports if the Record is ordered before the other
+// Record by their Nodes and then by adds before removes
+func recordBefore[T any](record Record[T], other Record[T]) bool {
+	if record.Node.Before(other.Node) {
+		return true
+	}
+	if other.Node.Before(record.Node) {
+		return false
+	}
+	return record.Type == AddOp && other.Type == RemoveOp
+}
+
+// History returns the Records of the operations on the given value
+// observed by the LWWSet, from the earliest to the latest retained
+func (lwwset KeyedSet[T, K]) History(value T) ([]Record[T], error) {
+	// Return an error if the value passed is nil
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if lwwset.store == nil || lwwset.store.history == nil {
+		return nil, ErrHistoryDisabled
+	}
+
+	values, ok := lwwset.store.history.values[key]
+	if !ok {
+		return []Record[T]{}, nil
+	}
+	return append([]Record[T]{}, values.records...), nil
+}
+
+// at returns the value present according to the Records
+// at or before the given time, if there is one. It returns
+// ErrHistoryTruncated if the Records deciding it were dropped
+// including Records of the value dropped by the garbage
+// collection before the given pruned time
+func (values *valueHistory[T]) at(bias Bias, pruned time.Time, timestamp time.Time) (T, bool, error) {
+	var value T
+	var add, remove *Node[T]
+
+	// Every dropped Record is older than the retained Records
+	// so the value is only known from the first retained Record
+	records := values.records
+	truncated := values.truncated || timestamp.Before(pruned)
+	if truncated && (len(records) == 0 || records[0].Node.Timestamp.After(timestamp)) {
+		return value, false, ErrHistoryTruncated
+	}
+
+	for index := range records {
+		if records[index].Node.Timestamp.After(timestamp) {
+			break
+		}
+		if records[index].Type == AddOp {
+			add = &records[index].Node
+		} else {
+			remove = &records[index].Node
+		}
+	}
+
+	if add == nil || add.expired(timestamp) || (remove != nil && !addWins(bias, *add, *remove)) {
+		return value, false, nil
+	}
+	return add.Value, true, nil
+}
+
+// LookupAt returns if the given value was present in the LWWSet
+// at the given time according to the operations in its history
+func (lwwset KeyedSet[T, K]) LookupAt(value T, timestamp time.Time) (bool, error) {
+	// Return an error if the value passed is nil
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return false, err
+	}
+
+	if lwwset.store == nil || lwwset.store.history == nil {
+		return false, ErrHistoryDisabled
+	}
+
+	// The value may have been garbage collected
+	// along with its Records before the time
+	history := lwwset.store.history
+	values, ok := history.values[key]
+	if !ok {
+		if timestamp.Before(history.pruned) {
+			return false, ErrHistoryTruncated
+		}
+		return false, nil
+	}
+
+	_, present, err := values.at(lwwset.store.bias, history.pruned, timestamp)
+	return present, err
+}
+
+// ListAt returns all the elements present in the LWWSet at the
+// given time according to the operations in its history
+func (lwwset KeyedSet[T, K]) ListAt(timestamp time.Time) ([]T, error) {
+	if lwwset.store == nil || lwwset.store.history == nil {
+		return nil, ErrHistoryDisabled
+	}
+
+	// Values garbage collected along with their
+	// Records may have been present before the time
+	history := lwwset.store.history
+	if timestamp.Before(history.pruned) {
+		return nil, ErrHistoryTruncated
+	}
+
+	list := []T{}
+	for _, key := range history.keys {
+		value, present, err := history.values[key].at(lwwset.store.bias, history.pruned, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		if present {
+			list = append(list, value)
+		}
+	}
+	return list, nil
+}
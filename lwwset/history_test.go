@@ -0,0 +1,224 @@
+package lwwset
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHistory checks that the History of a value holds the operations
+// made on it in order, including the ones superseded by later operations
+func TestHistory(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()), WithReplica("a"), WithHistory(0))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("xx")
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Addition("yy")
+
+	expectedValue := []Record[string]{
+		{Type: AddOp, Node: replicaNode("xx", 1, "a")},
+		{Type: RemoveOp, Node: replicaNode("xx", 2, "a")},
+		{Type: AddOp, Node: replicaNode("xx", 3, "a")},
+	}
+	actualValue, err := lwwset.History("xx")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestHistory_Merge checks that the History of a value holds the
+// operations merged from other LWWSets in order & only once
+func TestHistory_Merge(t *testing.T) {
+	lwwset := Initialize(WithHistory(0))
+	lwwset.addNode("xx", replicaNode("xx", 30, "a"))
+
+	other1 := Initialize()
+	other1.addNode("xx", replicaNode("xx", 10, "b"))
+	other2 := Initialize()
+	other2.removeNode("xx", replicaNode("xx", 20, "c"))
+
+	lwwset = lwwset.Join(other2, other1, other2)
+
+	expectedValue := []Record[string]{
+		{Type: AddOp, Node: replicaNode("xx", 10, "b")},
+		{Type: RemoveOp, Node: replicaNode("xx", 20, "c")},
+		{Type: AddOp, Node: replicaNode("xx", 30, "a")},
+	}
+	actualValue, _ := lwwset.History("xx")
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestHistory_Limit checks that the History of a value
+// only retains the latest Records up to the limit
+func TestHistory_Limit(t *testing.T) {
+	lwwset := Initialize(WithHistory(2))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("xx", node("xx", 20))
+	lwwset.addNode("xx", node("xx", 30))
+	lwwset.addNode("xx", node("xx", 5))
+
+	expectedValue := []Record[string]{
+		{Type: RemoveOp, Node: node("xx", 20)},
+		{Type: AddOp, Node: node("xx", 30)},
+	}
+	actualValue, _ := lwwset.History("xx")
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestHistory_Disabled checks that querying the History of
+// a LWWSet initialized without WithHistory returns an error
+func TestHistory_Disabled(t *testing.T) {
+	lwwset := Initialize()
+	lwwset, _ = lwwset.Addition("xx")
+
+	_, err := lwwset.History("xx")
+
+	assert.True(t, errors.Is(err, ErrHistoryDisabled))
+}
+
+// TestHistory_EmptyValue checks that the History
+// of an empty value cannot be queried
+func TestHistory_EmptyValue(t *testing.T) {
+	_, err := Initialize(WithHistory(0)).History("")
+
+	assert.Equal(t, errors.New("empty value provided"), err)
+}
+
+// TestHistory_Copy checks that the Records returned by
+// History are not changed by later operations
+func TestHistory_Copy(t *testing.T) {
+	lwwset := Initialize(WithClock(NewManualClock(time.Unix(0, 10))), WithHistory(0))
+	lwwset, _ = lwwset.Addition("xx")
+
+	records, _ := lwwset.History("xx")
+	lwwset, _ = lwwset.Removal("xx")
+
+	assert.Len(t, records, 1)
+}
+
+// TestLookupAt checks that LookupAt returns if a value
+// was present at a given time according to its History
+func TestLookupAt(t *testing.T) {
+	lwwset := Initialize(WithHistory(0))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("xx", node("xx", 20))
+	lwwset.addNode("xx", node("xx", 30))
+
+	for _, test := range []struct {
+		at       int64
+		expected bool
+	}{
+		{5, false},
+		{10, true},
+		{15, true},
+		{20, false},
+		{25, false},
+		{30, true},
+	} {
+		present, err := lwwset.LookupAt("xx", time.Unix(0, test.at))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, present, "at %d", test.at)
+	}
+
+	present, err := lwwset.LookupAt("yy", time.Unix(0, 30))
+	assert.Nil(t, err)
+	assert.False(t, present)
+}
+
+// TestLookupAt_Bias checks that LookupAt resolves a value added &
+// removed at the same time with the Bias of the LWWSet
+func TestLookupAt_Bias(t *testing.T) {
+	lwwset := Initialize(WithHistory(0), WithBias(AddBias))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("xx", node("xx", 10))
+
+	present, _ := lwwset.LookupAt("xx", time.Unix(0, 10))
+
+	assert.True(t, present)
+}
+
+// TestListAt checks that ListAt returns the values
+// present at a given time according to the History
+func TestListAt(t *testing.T) {
+	lwwset := Initialize(WithHistory(0))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.addNode("yy", node("yy", 20))
+	lwwset.removeNode("xx", node("xx", 30))
+
+	actualValue, err := lwwset.ListAt(time.Unix(0, 25))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"xx", "yy"}, actualValue)
+
+	actualValue, err = lwwset.ListAt(time.Unix(0, 30))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"yy"}, actualValue)
+
+	actualValue, err = lwwset.ListAt(time.Unix(0, 5))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, actualValue)
+}
+
+// TestListAt_GC checks that the Records of the values garbage collected
+// are dropped and that queries before them return an error
+func TestListAt_GC(t *testing.T) {
+	lwwset := Initialize(WithHistory(0))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("xx", node("xx", 20))
+	lwwset.addNode("yy", node("yy", 30))
+	lwwset.PruneTombstones(lwwset.Version())
+
+	history, _ := lwwset.History("xx")
+	assert.Empty(t, history)
+	assert.Equal(t, []string{"yy"}, lwwset.store.history.keys)
+
+	_, err := lwwset.ListAt(time.Unix(0, 15))
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+
+	_, err = lwwset.LookupAt("xx", time.Unix(0, 15))
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+
+	_, err = lwwset.LookupAt("yy", time.Unix(0, 15))
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+
+	actualValue, err := lwwset.ListAt(time.Unix(0, 30))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"yy"}, actualValue)
+
+	present, err := lwwset.LookupAt("xx", time.Unix(0, 25))
+	assert.Nil(t, err)
+	assert.False(t, present)
+}
+
+// TestLookupAt_Truncated checks that LookupAt returns an error for
+// a time before the earliest Record retained in the History
+func TestLookupAt_Truncated(t *testing.T) {
+	lwwset := Initialize(WithHistory(1))
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("xx", node("xx", 20))
+
+	_, err := lwwset.LookupAt("xx", time.Unix(0, 15))
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+
+	_, err = lwwset.ListAt(time.Unix(0, 15))
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+
+	present, err := lwwset.LookupAt("xx", time.Unix(0, 20))
+	assert.Nil(t, err)
+	assert.False(t, present)
+}
+
+// TestLookupAt_Disabled checks that querying a LWWSet initialized
+// without WithHistory at a given time returns an error
+func TestLookupAt_Disabled(t *testing.T) {
+	_, err := Initialize().LookupAt("xx", time.Unix(0, 10))
+	assert.True(t, errors.Is(err, ErrHistoryDisabled))
+
+	_, err = Initialize().ListAt(time.Unix(0, 10))
+	assert.True(t, errors.Is(err, ErrHistoryDisabled))
+}
@@ -0,0 +1,109 @@
+package lwwset
+
+import (
+	"sync"
+	"time"
+)
+
+// HLC is a Hybrid Logical Clock. Its timestamps follow the physical
+// time but never go backwards and always move past the timestamps
+// observed from other replicas, so an operation causally after
+// another one is always given a later timestamp even when the
+// physical clocks of the replicas drift apart.
+//
+// The logical counter is stored in the lowest logicalBits of the
+// timestamp's nanoseconds so HLC timestamps remain time.Time values
+// and are ordered by comparing their UnixNano.
+//
+// The timestamps observed are only followed up to maxDrift ahead of the
+// physical time so that a replica with a clock far ahead, or a single
+// bad timestamp, cannot move the timestamps of every replica forward
+type HLC struct {
+	mutex    sync.Mutex
+	physical Clock
+	maxDrift time.Duration
+	last     int64
+}
+
+const (
+	// logicalBits is the number of low bits of the
+	// nanoseconds used by the HLC logical counter
+	logicalBits = 16
+
+	// logicalMask masks the logical
+	// counter of a HLC timestamp
+	logicalMask = 1<<logicalBits - 1
+
+	// DefaultMaxDrift is the maximum drift of
+	// the timestamps observed by default
+	DefaultMaxDrift = time.Minute
+)
+
+// NewHLC returns a new HLC reading its physical time
+// from the given Clock or the system time if nil,
+// with a maximum drift of DefaultMaxDrift
+func NewHLC(physical Clock) *HLC {
+	if physical == nil {
+		physical = WallClock{}
+	}
+	return &HLC{physical: physical, maxDrift: DefaultMaxDrift}
+}
+
+// WithMaxDrift sets how far ahead of the physical time the
+// timestamps observed are followed, the HLC follows every
+// timestamp observed if not positive. It returns the HLC
+func (hlc *HLC) WithMaxDrift(maxDrift time.Duration) *HLC {
+	hlc.mutex.Lock()
+	defer hlc.mutex.Unlock()
+
+	hlc.maxDrift = maxDrift
+	return hlc
+}
+
+// Now returns the next HLC timestamp, which is
+// later than every timestamp returned or observed
+func (hlc *HLC) Now() time.Time {
+	hlc.mutex.Lock()
+	defer hlc.mutex.Unlock()
+
+	hlc.last++
+	if physical := hlc.physicalNow(); physical > hlc.last {
+		hlc.last = physical
+	}
+
+	return time.Unix(0, hlc.last)
+}
+
+// Read returns the physical time of the HLC
+// without issuing a new HLC timestamp
+func (hlc *HLC) Read() time.Time {
+	return hlc.physical.Now()
+}
+
+// Observe moves the HLC past the given timestamp received
+// from another replica, clamped to the maximum drift
+// ahead of the physical time
+func (hlc *HLC) Observe(timestamp time.Time) {
+	hlc.mutex.Lock()
+	defer hlc.mutex.Unlock()
+
+	remote := timestamp.UnixNano()
+	if limit := hlc.physicalNow() + int64(hlc.maxDrift); hlc.maxDrift > 0 && remote > limit {
+		remote = limit
+	}
+
+	if remote > hlc.last {
+		hlc.last = remote
+	}
+}
+
+// physicalNow returns the physical time in nanoseconds
+// with the bits of the logical counter cleared
+func (hlc *HLC) physicalNow() int64 {
+	return hlc.physical.Now().UnixNano() &^ logicalMask
+}
+
+// Logical returns the logical counter of a HLC timestamp
+func Logical(timestamp time.Time) int64 {
+	return timestamp.UnixNano() & logicalMask
+}
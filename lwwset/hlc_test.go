@@ -0,0 +1,110 @@
+package lwwset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHLC checks the basic functionality of the HLC
+// it follows the physical time with a zero logical counter
+func TestHLC(t *testing.T) {
+	physical := NewManualClock(time.Unix(0, 1<<20))
+	hlc := NewHLC(physical)
+
+	assert.Equal(t, time.Unix(0, 1<<20), hlc.Now())
+
+	physical.Advance(1 << 20)
+	timestamp := hlc.Now()
+
+	assert.Equal(t, time.Unix(0, 2<<20), timestamp)
+	assert.Equal(t, int64(0), Logical(timestamp))
+}
+
+// TestHLC_Read checks that reading the HLC returns
+// the physical time without moving the HLC
+func TestHLC_Read(t *testing.T) {
+	physical := NewManualClock(time.Unix(0, 1<<20))
+	hlc := NewHLC(physical)
+
+	hlc.Now()
+	assert.Equal(t, time.Unix(0, 1<<20), hlc.Read())
+	assert.Equal(t, time.Unix(0, 1<<20), hlc.Read())
+	assert.Equal(t, int64(1), Logical(hlc.Now()))
+}
+
+// TestHLC_SamePhysicalTime checks that the HLC increments the
+// logical counter when the physical time does not move
+func TestHLC_SamePhysicalTime(t *testing.T) {
+	physical := NewManualClock(time.Unix(0, 1<<20))
+	hlc := NewHLC(physical)
+
+	timestamp1 := hlc.Now()
+	timestamp2 := hlc.Now()
+
+	assert.True(t, timestamp1.Before(timestamp2))
+	assert.Equal(t, int64(1), Logical(timestamp2))
+}
+
+// TestHLC_PhysicalTimeBackwards checks that the HLC never
+// goes backwards when the physical time moves backwards
+func TestHLC_PhysicalTimeBackwards(t *testing.T) {
+	physical := NewManualClock(time.Unix(0, 2<<20))
+	hlc := NewHLC(physical)
+
+	timestamp1 := hlc.Now()
+	physical.Set(time.Unix(0, 1<<20))
+	timestamp2 := hlc.Now()
+
+	assert.True(t, timestamp1.Before(timestamp2))
+}
+
+// TestHLC_Observe checks that the HLC moves past a timestamp
+// observed from a replica with a physical clock ahead of it
+func TestHLC_Observe(t *testing.T) {
+	physical := NewManualClock(time.Unix(0, 1<<20))
+	hlc := NewHLC(physical)
+
+	remote := time.Unix(0, 5<<20+3)
+	hlc.Observe(remote)
+
+	assert.True(t, remote.Before(hlc.Now()))
+}
+
+// TestHLC_ObserveMaxDrift checks that the HLC only moves up to
+// the maximum drift past a timestamp observed too far ahead
+func TestHLC_ObserveMaxDrift(t *testing.T) {
+	physical := NewManualClock(time.Unix(0, 1<<20))
+	hlc := NewHLC(physical).WithMaxDrift(4 << 20)
+
+	hlc.Observe(time.Unix(0, 100<<20))
+	assert.Equal(t, time.Unix(0, 5<<20+1), hlc.Now())
+
+	// The physical time catching up
+	// moves the HLC past the drift
+	physical.Set(time.Unix(0, 6<<20))
+	assert.Equal(t, time.Unix(0, 6<<20), hlc.Now())
+
+	// The HLC follows every timestamp
+	// observed without a maximum drift
+	hlc.WithMaxDrift(0).Observe(time.Unix(0, 100<<20))
+	assert.Equal(t, time.Unix(0, 100<<20+1), hlc.Now())
+}
+
+// TestHLC_MergeSlowClock checks that a value added after a merge on a
+// replica whose physical clock is behind is not lost to the earlier
+// removal made on a replica whose physical clock is ahead
+func TestHLC_MergeSlowClock(t *testing.T) {
+	fast := Initialize(WithClock(NewHLC(NewManualClock(time.Unix(0, 10<<20)))))
+	slow := Initialize(WithClock(NewHLC(NewManualClock(time.Unix(0, 1<<20)))))
+
+	fast, _ = fast.Addition("xx")
+	fast, _ = fast.Removal("xx")
+
+	slow = Merge(slow, fast)
+	slow, _ = slow.Addition("xx")
+
+	present, _ := Merge(fast, slow).Lookup("xx")
+	assert.True(t, present)
+}
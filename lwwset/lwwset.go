@@ -2,6 +2,7 @@ package lwwset
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -9,87 +10,384 @@ import (
 // to append, remove, list & lookup values in a LWWSet. It also provides the functionality to merge multiple
 // LWWSets together and a utility function to clear a LWWSet used in tests
 
-// LWWSet is the LWWSet CRDT data type
-// It is implemented by combining two LWWNodes,
-// One to store the values added & another
-// to store the values removed
-type LWWSet struct {
-	// Add is a LWWNodeSlice to store the values added
-	Add LWWNodeSlice `json:"add"`
-	// Remove is a LWWNodeSlice to store the values removed
-	Remove LWWNodeSlice `json:"remove"`
+// KeyedSet is the LWWSet CRDT data type over values of any type
+// which are identified by the comparable key returned by a key function
+// It is implemented by indexing for each key the latest Node of the
+// value added & the latest Node of the value removed. The methods
+// changing a KeyedSet have pointer receivers & change it in place
+// A KeyedSet is a handle to its storage so copies of a KeyedSet
+// share the same values, Clone returns an independent copy
+type KeyedSet[T any, K comparable] struct {
+	store *store[T, K]
 }
 
-// LWWNode stores a given value
+// store is the storage shared by
+// the copies of a KeyedSet
+type store[T any, K comparable] struct {
+	// entries indexes the added &
+	// removed Nodes by their key
+	entries map[K]*entry[T]
+	// keys are the keys of the entries
+	// in the order they were first seen
+	keys []K
+	// version is incremented on every
+	// change made to the entries
+	version uint64
+
+	// bias decides if a value added & removed with the same
+	// timestamp is present, it is carried with the LWWSet
+	// so that LWWSets with different biases are not merged
+	bias Bias
+	// clock provides the timestamps for the values
+	// added & removed, the system time is used if nil
+	clock Clock
+	// replica identifies the node owning the LWWSet
+	// and is stamped on the values added & removed
+	replica string
+	// key returns the key identifying a value,
+	// the value itself is the key if nil
+	key func(T) K
+	// opLog records the operations made on the
+	// LWWSet if op-based replication is enabled
+	opLog *opLog[T]
+	// history records the operations on each value
+	// of the LWWSet if the history mode is enabled
+	history *history[T, K]
+}
+
+// entry holds the latest Nodes of a value added &
+// removed, the Node superseded by the other is dropped
+type entry[T any] struct {
+	add    *Node[T]
+	remove *Node[T]
+	// version is the version of the
+	// store when the entry last changed
+	version uint64
+}
+
+// Set is the LWWSet CRDT data type over
+// values of any comparable type
+type Set[T comparable] = KeyedSet[T, T]
+
+// LWWSet is the LWWSet CRDT data
+// type over string values
+type LWWSet = Set[string]
+
+// Node stores a given value
 // along with a timestamp of
-// when it was added
-type LWWNode struct {
-	Value     string
+// when it was added, the
+// replica that added it, when
+// it expires if added with a
+// TTL and the metadata it was
+// added with. It is encoded
+// in JSON with the timestamps
+// in nanoseconds
+type Node[T any] struct {
+	Value     T
 	Timestamp time.Time
+	Replica   string `json:",omitempty"`
+	Expiry    time.Time
+	Metadata  Metadata `json:",omitempty"`
 }
 
+// NodeSlice is a
+// collection of Nodes
+type NodeSlice[T any] []Node[T]
+
+// LWWNode is the Node
+// of a string value
+type LWWNode = Node[string]
+
 // LWWNodeSlice is a
 // collection of LWWNodes
-type LWWNodeSlice []LWWNode
+type LWWNodeSlice = NodeSlice[string]
+
+// Before reports if the Node is ordered before the given Node
+// Nodes are totally ordered by their timestamp and then by their
+// replica, so concurrent additions, or concurrent removals, with
+// the same timestamp on different replicas are resolved the same
+// way on every replica. An addition & a removal with the same
+// timestamp are resolved by the Bias instead, see addWins
+func (node Node[T]) Before(other Node[T]) bool {
+	if node.Timestamp.UnixNano() != other.Timestamp.UnixNano() {
+		return node.Timestamp.UnixNano() < other.Timestamp.UnixNano()
+	}
+	return node.Replica < other.Replica
+}
 
 // Initialize returns a new empty LWWSet
-func Initialize() LWWSet {
-	return LWWSet{
-		Add:    LWWNodeSlice{},
-		Remove: LWWNodeSlice{},
+// configured with the given options
+func Initialize(opts ...Option) LWWSet {
+	return New[string](opts...)
+}
+
+// New returns a new empty Set of comparable
+// values configured with the given options
+func New[T comparable](opts ...Option) Set[T] {
+	return NewKeyed[T, T](nil, opts...)
+}
+
+// NewKeyed returns a new empty KeyedSet whose values are identified
+// by the key function and configured with the given options
+func NewKeyed[T any, K comparable](key func(T) K, opts ...Option) KeyedSet[T, K] {
+	options := options{bias: RemoveBias}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	lwwset := KeyedSet[T, K]{
+		store: &store[T, K]{
+			entries: map[K]*entry[T]{},
+			keys:    []K{},
+			bias:    options.bias,
+			clock:   options.clock,
+			replica: options.replica,
+			key:     key,
+		},
+	}
+
+	if options.opLogLimit > 0 {
+		lwwset.store.opLog = &opLog[T]{seen: map[string]struct{}{}, limit: options.opLogLimit}
+	}
+
+	if options.historyLimit > 0 {
+		lwwset.store.history = &history[T, K]{values: map[K]*valueHistory[T]{}, limit: options.historyLimit}
+	}
+
+	return lwwset
+}
+
+// init allocates the storage of the
+// KeyedSet if it is the zero KeyedSet
+func (lwwset *KeyedSet[T, K]) init() KeyedSet[T, K] {
+	if lwwset.store == nil {
+		*lwwset = NewKeyed[T, K](nil)
+	}
+	return *lwwset
+}
+
+// Clone returns a copy of the LWWSet which does not share its
+// values, its op log & its history with the LWWSet. The copy
+// keeps the Clock, replica, Bias & key function of the LWWSet
+func (lwwset KeyedSet[T, K]) Clone() KeyedSet[T, K] {
+	clone := NewKeyed[T, K](nil)
+	if lwwset.store == nil {
+		return clone
 	}
+
+	store := *lwwset.store
+	store.entries = make(map[K]*entry[T], len(lwwset.store.entries))
+	for key, lwwentry := range lwwset.store.entries {
+		cloned := *lwwentry
+		store.entries[key] = &cloned
+	}
+	store.keys = append([]K{}, lwwset.store.keys...)
+
+	if lwwset.store.opLog != nil {
+		store.opLog = lwwset.store.opLog.clone()
+	}
+	if lwwset.store.history != nil {
+		store.history = lwwset.store.history.clone()
+	}
+
+	clone.store = &store
+	return clone
+}
+
+// SetKey sets the key function identifying the values of the
+// LWWSet, used when the LWWSet was decoded or is the zero KeyedSet
+func (lwwset *KeyedSet[T, K]) SetKey(key func(T) K) KeyedSet[T, K] {
+	lwwset.init()
+	lwwset.store.key = key
+	return *lwwset
+}
+
+// Bias returns the Bias of the LWWSet
+func (lwwset KeyedSet[T, K]) Bias() Bias {
+	if lwwset.store == nil {
+		return ""
+	}
+	return lwwset.store.bias
+}
+
+// now returns the current timestamp from the
+// LWWSet's Clock or the system time if not set
+func (lwwset KeyedSet[T, K]) now() time.Time {
+	if lwwset.store.clock == nil {
+		return time.Now()
+	}
+	return lwwset.store.clock.Now()
+}
+
+// read returns the current time from the LWWSet's Clock
+// without issuing a new timestamp, used to check the
+// expiry of the values when they are read
+func (lwwset KeyedSet[T, K]) read() time.Time {
+	if reader, ok := lwwset.store.clock.(Reader); ok {
+		return reader.Read()
+	}
+	return lwwset.now()
+}
+
+// ErrEmptyValue is returned when an
+// empty value is added, removed or looked up
+var ErrEmptyValue = errors.New("empty value provided")
+
+// ErrNoKey is returned when the values of a KeyedSet are
+// not its keys and it has no key function to identify them
+var ErrNoKey = errors.New("no key function provided")
+
+// keyOf returns the key identifying the given value
+// It returns ErrNoKey if the value is not a key and
+// the LWWSet has no key function
+func (lwwset KeyedSet[T, K]) keyOf(value T) (K, error) {
+	if lwwset.store == nil || lwwset.store.key == nil {
+		key, ok := any(value).(K)
+		if !ok {
+			return key, ErrNoKey
+		}
+		return key, nil
+	}
+	return lwwset.store.key(value), nil
+}
+
+// validKey returns the key identifying the given value
+// or ErrEmptyValue if the value is empty
+func (lwwset KeyedSet[T, K]) validKey(value T) (K, error) {
+	key, err := lwwset.keyOf(value)
+	if err == nil && isEmpty(key) {
+		err = ErrEmptyValue
+	}
+	return key, err
+}
+
+// isEmpty checks if the given key is an empty string
+func isEmpty[K comparable](key K) bool {
+	value, ok := any(key).(string)
+	return ok && value == ""
 }
 
 // Addition adds a new unique value to the Add LWWSet
-func (lwwset LWWSet) Addition(value string) (LWWSet, error) {
+func (lwwset *KeyedSet[T, K]) Addition(value T) (KeyedSet[T, K], error) {
 	// Return an error if the value passed is nil
-	if value == "" {
-		return lwwset, errors.New("empty value provided")
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return *lwwset, err
 	}
 
-	// Order the LWWSet according
-	// to the timestamps
-	lwwset = lwwset.orderList()
+	lwwset.init()
 
-	// Set = Set U value
-	if !isPresent(value, lwwset.Add) {
-		lwwset.Add = append(lwwset.Add, LWWNode{Value: value, Timestamp: time.Now()})
-	}
+	// Set = Set U value, refreshing the timestamp
+	// if the value was already added
+	node := Node[T]{Value: value, Timestamp: lwwset.now(), Replica: lwwset.store.replica}
+	lwwset.addNode(key, node)
+	lwwset.record(Op[T]{ID: newOpID(), Type: AddOp, Node: node})
 
 	// Return the new LWWSet
 	// followed by nil error
-	return lwwset, nil
+	return *lwwset, nil
 }
 
 // Removal adds a new unique value to the Remove LWWSet
-func (lwwset LWWSet) Removal(value string) (LWWSet, error) {
+func (lwwset *KeyedSet[T, K]) Removal(value T) (KeyedSet[T, K], error) {
 	// Return an error if the value passed is nil
-	if value == "" {
-		return lwwset, errors.New("empty value provided")
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return *lwwset, err
 	}
 
-	// Order the LWWSet according
-	// to the timestamps
-	lwwset = lwwset.orderList()
+	lwwset.init()
 
-	// Set = Set U value
-	if !isPresent(value, lwwset.Remove) {
-		lwwset.Remove = append(lwwset.Remove, LWWNode{Value: value, Timestamp: time.Now()})
-	}
+	// Set = Set U value, refreshing the timestamp
+	// if the value was already removed
+	node := Node[T]{Value: value, Timestamp: lwwset.now(), Replica: lwwset.store.replica}
+	lwwset.removeNode(key, node)
+	lwwset.record(Op[T]{ID: newOpID(), Type: RemoveOp, Node: node})
 
 	// Return the new LWWSet
 	// followed by nil error
-	return lwwset, nil
+	return *lwwset, nil
+}
+
+// entry returns the entry of the given
+// key creating it if it does not exist
+func (lwwset KeyedSet[T, K]) entry(key K) *entry[T] {
+	lwwentry, ok := lwwset.store.entries[key]
+	if !ok {
+		lwwentry = &entry[T]{}
+		lwwset.store.entries[key] = lwwentry
+		lwwset.store.keys = append(lwwset.store.keys, key)
+	}
+	return lwwentry
+}
+
+// addNode keeps the given Node as the latest
+// added Node of its value if it is later
+func (lwwset KeyedSet[T, K]) addNode(key K, node Node[T]) {
+	lwwset.remember(AddOp, key, node)
+
+	lwwentry := lwwset.entry(key)
+	previous := *lwwentry
+	if lwwentry.add == nil || lwwentry.add.Before(node) {
+		lwwentry.add = &node
+	}
+	lwwset.resolve(lwwentry, previous)
+}
+
+// removeNode keeps the given Node as the latest
+// removed Node of its value if it is later
+func (lwwset KeyedSet[T, K]) removeNode(key K, node Node[T]) {
+	lwwset.remember(RemoveOp, key, node)
+
+	lwwentry := lwwset.entry(key)
+	previous := *lwwentry
+	if lwwentry.remove == nil || lwwentry.remove.Before(node) {
+		lwwentry.remove = &node
+	}
+	lwwset.resolve(lwwentry, previous)
+}
+
+// resolve drops the Node of the entry superseded by the other
+// Node and moves the entry to a new version if it changed
+func (lwwset KeyedSet[T, K]) resolve(lwwentry *entry[T], previous entry[T]) {
+	lwwset.supersede(lwwentry)
+
+	if lwwentry.add != previous.add || lwwentry.remove != previous.remove {
+		lwwset.store.version++
+		lwwentry.version = lwwset.store.version
+	}
+}
+
+// supersede drops the Node of the
+// entry superseded by the other Node
+func (lwwset KeyedSet[T, K]) supersede(lwwentry *entry[T]) {
+	// An element is a member of the LWW-Element-Set if it is in the add set, and either not in the remove
+	// set, or in the remove set but with an earlier timestamp than the latest timestamp in the add set.
+	if lwwentry.add == nil || lwwentry.remove == nil {
+		return
+	}
+
+	// The add supersedes the remove so
+	// the stale remove can be dropped
+	if addWins(lwwset.store.bias, *lwwentry.add, *lwwentry.remove) {
+		lwwentry.remove = nil
+		return
+	}
+
+	// The remove supersedes the add, only the add is dropped
+	// as the remove is needed so that merging with a replica
+	// still holding the older add does not add it back
+	lwwentry.add = nil
 }
 
 // GetValues extracts all the values
-// present in the LWWNode slice
-func (list LWWNodeSlice) GetValues() []string {
+// present in the Node slice
+func (list NodeSlice[T]) GetValues() []T {
 	if len(list) == 0 {
-		return []string{}
+		return []T{}
 	}
 
-	values := make([]string, 0)
+	values := make([]T, 0, len(list))
 
 	for _, lwwnode := range list {
 		values = append(values, lwwnode.Value)
@@ -98,53 +396,63 @@ func (list LWWNodeSlice) GetValues() []string {
 	return values
 }
 
-// List returns all the elements present in the LWWSet
-func (lwwset LWWSet) List() (LWWSet, []string) {
-	lwwset = lwwset.orderList()
-	return lwwset, lwwset.Add.GetValues()
-}
+// AddNodes returns the latest Nodes of the
+// values added in the order they were first seen
+func (lwwset KeyedSet[T, K]) AddNodes() NodeSlice[T] {
+	list := NodeSlice[T]{}
+	if lwwset.store == nil {
+		return list
+	}
 
-// orderList iterates over the list and does
-// a look up if an element is present or not
-func (lwwset LWWSet) orderList() LWWSet {
-	// An element is a member of the LWW-Element-Set if it is in the add set, and either not in the remove
-	// set, or in the remove set but with an earlier timestamp than the latest timestamp in the add set.
-	for _, lwwNode := range lwwset.Add {
-		if !isPresent(lwwNode.Value, lwwset.Remove) || latestValue(lwwNode.Value, lwwset.Remove).Timestamp.UnixNano() < lwwNode.Timestamp.UnixNano() {
-			continue
+	for _, key := range lwwset.store.keys {
+		if node := lwwset.store.entries[key].add; node != nil {
+			list = append(list, *node)
 		}
-		lwwset.Add = Delete(lwwset.Add, lwwNode.Value)
-		lwwset.Remove = Delete(lwwset.Remove, lwwNode.Value)
 	}
-	return lwwset
+	return list
 }
 
-// isPresent checks if a given value
-// is present in the list or not
-func isPresent(value string, list LWWNodeSlice) bool {
-	for _, element := range list {
-		if element.Value == value {
-			return true
+// RemoveNodes returns the latest Nodes of the
+// values removed in the order they were first seen
+func (lwwset KeyedSet[T, K]) RemoveNodes() NodeSlice[T] {
+	list := NodeSlice[T]{}
+	if lwwset.store == nil {
+		return list
+	}
+
+	for _, key := range lwwset.store.keys {
+		if node := lwwset.store.entries[key].remove; node != nil {
+			list = append(list, *node)
 		}
 	}
-	return false
+	return list
+}
+
+// String returns the latest Nodes of the values
+// added & removed, as printed in the logs
+func (lwwset KeyedSet[T, K]) String() string {
+	return fmt.Sprintf("{%v %v}", lwwset.AddNodes(), lwwset.RemoveNodes())
 }
 
-// latestValue returns the latest value in a
-// LWWNodeSlice according to the timestamp
-func latestValue(value string, list LWWNodeSlice) LWWNode {
-	maxNode := LWWNode{Value: value}
-	for _, element := range list {
-		if element.Value == maxNode.Value && element.Timestamp.UnixNano() > maxNode.Timestamp.UnixNano() {
-			maxNode = element
+// List returns all the elements present in the LWWSet
+func (lwwset KeyedSet[T, K]) List() (KeyedSet[T, K], []T) {
+	if lwwset.store == nil {
+		return lwwset, []T{}
+	}
+
+	now := lwwset.read()
+	values := make([]T, 0, len(lwwset.store.keys))
+	for _, key := range lwwset.store.keys {
+		if node := lwwset.store.entries[key].add; node != nil && !node.expired(now) {
+			values = append(values, node.Value)
 		}
 	}
-	return maxNode
+	return lwwset, values
 }
 
-// Delete removes an entry from the LWWNodeSlice list
-func Delete(list LWWNodeSlice, value string) LWWNodeSlice {
-	newList := LWWNodeSlice{}
+// Delete removes an entry from the NodeSlice list
+func Delete[T comparable](list NodeSlice[T], value T) NodeSlice[T] {
+	newList := NodeSlice[T]{}
 	for _, node := range list {
 		if node.Value != value {
 			newList = append(newList, node)
@@ -155,51 +463,96 @@ func Delete(list LWWNodeSlice, value string) LWWNodeSlice {
 
 // Lookup returns either boolean true/false indicating
 // if a given value is present in the LWWSet or not
-func (lwwset LWWSet) Lookup(value string) (bool, error) {
+func (lwwset KeyedSet[T, K]) Lookup(value T) (bool, error) {
 	// Return an error if the value passed is nil
-	if value == "" {
-		return false, errors.New("empty value provided")
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return false, err
 	}
 
-	lwwset, list := lwwset.List()
+	if lwwset.store == nil {
+		return false, nil
+	}
 
-	// Iterative over the LWWSet and check if the
-	// value is the one we're searching
-	// return true if the value exists
-	for _, element := range list {
-		if element == value {
-			return true, nil
-		}
+	// The value is present if its latest
+	// Node is an added Node not expired
+	lwwentry, ok := lwwset.store.entries[key]
+	return ok && lwwentry.add != nil && !lwwentry.add.expired(lwwset.read()), nil
+}
+
+// Get returns the value present in the LWWSet
+// identified by the given key if there is one
+func (lwwset KeyedSet[T, K]) Get(key K) (T, bool) {
+	var value T
+	if lwwset.store == nil {
+		return value, false
 	}
 
-	// If the value isn't found after iterating
-	// over the entire LWWSet we return false
-	return false, nil
+	lwwentry, ok := lwwset.store.entries[key]
+	if !ok || lwwentry.add == nil || lwwentry.add.expired(lwwset.read()) {
+		return value, false
+	}
+	return lwwentry.add.Value, true
 }
 
-// Merge conbines multiple LWWSets together using Union
-// and returns a single merged LWWSet
-func Merge(LWWSets ...LWWSet) LWWSet {
-	var LWWSetMerged LWWSet
+// Join merges the given LWWSets into the LWWSet in place
+// keeping for each value the latest added & removed Nodes
+func (lwwset *KeyedSet[T, K]) Join(LWWSets ...KeyedSet[T, K]) KeyedSet[T, K] {
+	lwwset.init()
 
-	// LWWSetMerged = LWWSetMerged U LWWSetToMergeWith
-	for _, lwwset := range LWWSets {
-		for _, lwwnode := range lwwset.Add {
-			if lwwnode.Value == "" {
-				continue
-			}
-			LWWSetMerged, _ = LWWSetMerged.Addition(lwwnode.Value)
+	// Clocks such as the HLC move past
+	// the timestamps being merged
+	observer, _ := lwwset.store.clock.(Observer)
+
+	for _, other := range LWWSets {
+		if other.store == nil {
+			continue
+		}
+		if lwwset.store.key == nil {
+			lwwset.store.key = other.store.key
 		}
-		for _, lwwnode := range lwwset.Remove {
-			if lwwnode.Value == "" {
+
+		for _, key := range other.store.keys {
+			if isEmpty(key) {
 				continue
 			}
-			LWWSetMerged, _ = LWWSetMerged.Removal(lwwnode.Value)
+
+			otherEntry := other.store.entries[key]
+			if otherEntry.add != nil {
+				lwwset.addNode(key, *otherEntry.add)
+				if observer != nil {
+					observer.Observe(otherEntry.add.Timestamp)
+				}
+			}
+			if otherEntry.remove != nil {
+				lwwset.removeNode(key, *otherEntry.remove)
+				if observer != nil {
+					observer.Observe(otherEntry.remove.Timestamp)
+				}
+			}
 		}
 	}
 
-	// Return the merged LWWSet followed by nil error
-	return LWWSetMerged
+	return *lwwset
+}
+
+// Merge conbines multiple LWWSets together using Union
+// and returns a single merged LWWSet. The original timestamps
+// are preserved and for each value only the node with the
+// latest timestamp is kept in both the Add & Remove LWWNodes,
+// making Merge commutative, associative & idempotent.
+// The merged LWWSet uses the Clock, replica & Bias of the first
+// LWWSet, Compatible is to be checked before merging LWWSets
+func Merge[T any, K comparable](LWWSets ...KeyedSet[T, K]) KeyedSet[T, K] {
+	LWWSetMerged := NewKeyed[T, K](nil)
+	if len(LWWSets) != 0 && LWWSets[0].store != nil {
+		LWWSetMerged.store.bias = LWWSets[0].store.bias
+		LWWSetMerged.store.clock = LWWSets[0].store.clock
+		LWWSetMerged.store.replica = LWWSets[0].store.replica
+	}
+
+	// LWWSetMerged = LWWSetMerged U LWWSetToMergeWith
+	return LWWSetMerged.Join(LWWSets...)
 }
 
 // Clear is utility function used only for tests
@@ -0,0 +1,116 @@
+package lwwset
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// benchmarkSizes are the number of values
+// in the LWWSets benchmarked
+var benchmarkSizes = []int{100, 1000, 10000, 100000}
+
+// benchmarkSet returns a LWWSet with the given number
+// of values added, every tenth value also being removed
+func benchmarkSet(size int) LWWSet {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+	for i := 0; i < size; i++ {
+		lwwset, _ = lwwset.Addition(strconv.Itoa(i))
+	}
+	for i := 0; i < size; i += 10 {
+		lwwset, _ = lwwset.Removal(strconv.Itoa(i))
+	}
+	return lwwset
+}
+
+// BenchmarkAddition measures adding a
+// new value to a LWWSet of a given size
+func BenchmarkAddition(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			lwwset := benchmarkSet(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				lwwset, _ = lwwset.Addition(strconv.Itoa(size + i%size))
+			}
+		})
+	}
+}
+
+// BenchmarkRemoval measures removing a
+// value from a LWWSet of a given size
+func BenchmarkRemoval(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			lwwset := benchmarkSet(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				lwwset, _ = lwwset.Removal(strconv.Itoa(i % size))
+			}
+		})
+	}
+}
+
+// BenchmarkLookup measures looking up a
+// value in a LWWSet of a given size
+func BenchmarkLookup(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			lwwset := benchmarkSet(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				lwwset.Lookup(strconv.Itoa(i % size))
+			}
+		})
+	}
+}
+
+// BenchmarkList measures listing all
+// the values of a LWWSet of a given size
+func BenchmarkList(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			lwwset := benchmarkSet(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				lwwset.List()
+			}
+		})
+	}
+}
+
+// BenchmarkMerge measures merging two
+// LWWSets of a given size together
+func BenchmarkMerge(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			lwwset1 := benchmarkSet(size)
+			lwwset2 := benchmarkSet(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				Merge(lwwset1, lwwset2)
+			}
+		})
+	}
+}
+
+// BenchmarkApplyBatch measures applying a batch of adds &
+// removes of the given size to a LWWSet of the same size
+func BenchmarkApplyBatch(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			lwwset := benchmarkSet(size)
+			ops := make([]BatchOp[string], size)
+			for i := range ops {
+				ops[i] = BatchOp[string]{Type: AddOp, Value: strconv.Itoa(size + i)}
+				if i%2 == 0 {
+					ops[i] = BatchOp[string]{Type: RemoveOp, Value: strconv.Itoa(i)}
+				}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				lwwset, _ = lwwset.ApplyBatch(ops)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package lwwset
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -198,3 +200,243 @@ func TestLookup_EmptyLookup(t *testing.T) {
 
 	lwwset = Clear()
 }
+
+// fromNodes is a test helper to build a LWWSet
+// from the LWWNodes of the values added & removed
+func fromNodes(add LWWNodeSlice, remove LWWNodeSlice) LWWSet {
+	lwwset := Initialize()
+	for _, lwwnode := range add {
+		lwwset.addNode(lwwnode.Value, lwwnode)
+	}
+	for _, lwwnode := range remove {
+		lwwset.removeNode(lwwnode.Value, lwwnode)
+	}
+	return lwwset
+}
+
+// node is a test helper to build a LWWNode
+// with a fixed timestamp in nanoseconds
+func node(value string, timestamp int64) LWWNode {
+	return LWWNode{Value: value, Timestamp: time.Unix(0, timestamp)}
+}
+
+// TestMerge checks the basic functionality of LWWSet Merge()
+// it returns the union of the values in the LWWSets merged
+func TestMerge(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 1)}, LWWNodeSlice{})
+	lwwset2 := fromNodes(LWWNodeSlice{node("yy", 2)}, LWWNodeSlice{})
+
+	expectedValue := []string{"xx", "yy"}
+	_, actualValue := Merge(lwwset1, lwwset2).List()
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestMerge_PreservesTimestamp checks that LWWSet Merge() keeps the
+// original timestamps of the LWWNodes instead of restamping them
+func TestMerge_PreservesTimestamp(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{})
+	lwwset2 := fromNodes(LWWNodeSlice{}, LWWNodeSlice{node("yy", 20)})
+
+	actualValue := Merge(lwwset1, lwwset2)
+
+	assert.Equal(t, LWWNodeSlice{node("xx", 10)}, actualValue.AddNodes())
+	assert.Equal(t, LWWNodeSlice{node("yy", 20)}, actualValue.RemoveNodes())
+}
+
+// TestMerge_LatestTimestamp checks that LWWSet Merge() keeps only the
+// latest LWWNode for each value irrespective of the order, dropping the
+// added LWWNode superseded by the removed LWWNode
+func TestMerge_LatestTimestamp(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 30)}, LWWNodeSlice{node("xx", 20)})
+	lwwset2 := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("xx", 40)})
+
+	assert.Equal(t, LWWNodeSlice{}, Merge(lwwset1, lwwset2).AddNodes())
+	assert.Equal(t, LWWNodeSlice{node("xx", 40)}, Merge(lwwset1, lwwset2).RemoveNodes())
+	assert.Equal(t, LWWNodeSlice{node("xx", 40)}, Merge(lwwset2, lwwset1).RemoveNodes())
+
+	present, err := Merge(lwwset1, lwwset2).Lookup("xx")
+	assert.Nil(t, err)
+	assert.False(t, present)
+}
+
+// TestMerge_LaterAddition checks that LWWSet Merge() keeps a value
+// added on one LWWSet after it was removed on another LWWSet
+func TestMerge_LaterAddition(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 30)}, LWWNodeSlice{})
+	lwwset2 := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("xx", 20)})
+
+	expectedValue := []string{"xx"}
+	_, actualValue := Merge(lwwset2, lwwset1).List()
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestMerge_RemovedNotReAdded checks that a value removed and then listed
+// on a LWWSet is not added back when merged with a LWWSet still holding
+// the older addition of that value
+func TestMerge_RemovedNotReAdded(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("xx", 20)})
+	lwwset1, _ = lwwset1.List()
+	lwwset2 := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{})
+
+	expectedValue := []string{}
+	_, actualValue := Merge(lwwset1, lwwset2).List()
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestMerge_Commutative checks that the order in which
+// LWWSets are merged does not change the merged result
+func TestMerge_Commutative(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 10), node("yy", 30)}, LWWNodeSlice{node("zz", 5)})
+	lwwset2 := fromNodes(LWWNodeSlice{node("zz", 20), node("xx", 5)}, LWWNodeSlice{node("yy", 40)})
+
+	merged1 := Merge(lwwset1, lwwset2)
+	merged2 := Merge(lwwset2, lwwset1)
+
+	assert.ElementsMatch(t, merged1.AddNodes(), merged2.AddNodes())
+	assert.ElementsMatch(t, merged1.RemoveNodes(), merged2.RemoveNodes())
+
+	_, list1 := merged1.List()
+	_, list2 := merged2.List()
+	assert.ElementsMatch(t, []string{"xx", "zz"}, list1)
+	assert.ElementsMatch(t, list1, list2)
+}
+
+// TestMerge_Associative checks that the grouping in which
+// LWWSets are merged does not change the merged result
+func TestMerge_Associative(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{}, LWWNodeSlice{node("xx", 20)})
+	lwwset2 := fromNodes(LWWNodeSlice{node("xx", 10), node("yy", 10)}, LWWNodeSlice{})
+	lwwset3 := fromNodes(LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("yy", 5)})
+
+	lwwset12, _ := Merge(lwwset1, lwwset2).List()
+	lwwset23, _ := Merge(lwwset2, lwwset3).List()
+
+	_, list1 := Merge(lwwset12, lwwset3).List()
+	_, list2 := Merge(lwwset1, lwwset23).List()
+
+	assert.ElementsMatch(t, []string{"yy"}, list1)
+	assert.ElementsMatch(t, list1, list2)
+}
+
+// TestMerge_Idempotent checks that merging a LWWSet
+// with itself does not change the LWWSet
+func TestMerge_Idempotent(t *testing.T) {
+	lwwset1 := fromNodes(LWWNodeSlice{node("xx", 10), node("yy", 30)}, LWWNodeSlice{node("yy", 20)})
+
+	assert.Equal(t, lwwset1.AddNodes(), Merge(lwwset1, lwwset1).AddNodes())
+	assert.Equal(t, lwwset1.RemoveNodes(), Merge(lwwset1, lwwset1).RemoveNodes())
+	assert.Equal(t, Merge(lwwset1).AddNodes(), Merge(Merge(lwwset1), lwwset1).AddNodes())
+}
+
+// replicaNode is a test helper to build a LWWNode
+// with a fixed timestamp & replica
+func replicaNode(value string, timestamp int64, replica string) LWWNode {
+	return LWWNode{Value: value, Timestamp: time.Unix(0, timestamp), Replica: replica}
+}
+
+// TestLWWNode_Before checks that LWWNodes are ordered
+// by their timestamp and then by their replica
+func TestLWWNode_Before(t *testing.T) {
+	assert.True(t, replicaNode("xx", 1, "b").Before(replicaNode("xx", 2, "a")))
+	assert.True(t, replicaNode("xx", 1, "a").Before(replicaNode("xx", 1, "b")))
+	assert.False(t, replicaNode("xx", 1, "b").Before(replicaNode("xx", 1, "a")))
+	assert.False(t, replicaNode("xx", 1, "a").Before(replicaNode("xx", 1, "a")))
+}
+
+// TestWithReplica checks that a LWWSet initialized with a
+// replica ID stamps it on the values added & removed
+func TestWithReplica(t *testing.T) {
+	lwwset := Initialize(WithClock(NewManualClock(time.Unix(0, 10))), WithReplica("a"))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Removal("yy")
+
+	assert.Equal(t, LWWNodeSlice{replicaNode("xx", 10, "a")}, lwwset.AddNodes())
+	assert.Equal(t, LWWNodeSlice{replicaNode("yy", 10, "a")}, lwwset.RemoveNodes())
+}
+
+// TestMerge_SameTimestampAddRemove checks that an addition & a removal of
+// the same value with the same timestamp on different replicas converge
+// to the result of the Bias irrespective of the order the LWWSets are
+// merged in, which is the value being absent with the RemoveBias
+func TestMerge_SameTimestampAddRemove(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+
+	lwwsetA := Initialize(WithClock(clock), WithReplica("a"))
+	lwwsetB := Initialize(WithClock(clock), WithReplica("b"))
+
+	lwwsetA, _ = lwwsetA.Removal("xx")
+	lwwsetB, _ = lwwsetB.Addition("xx")
+	lwwsetB, _ = lwwsetB.Addition("yy")
+	lwwsetA, _ = lwwsetA.Addition("yy")
+	lwwsetB, _ = lwwsetB.Removal("yy")
+
+	_, listAB := Merge(lwwsetA, lwwsetB).List()
+	_, listBA := Merge(lwwsetB, lwwsetA).List()
+
+	assert.Equal(t, []string{}, listAB)
+	assert.Equal(t, []string{}, listBA)
+}
+
+// TestMerge_SameTimestampAdd checks that additions of the same value
+// with the same timestamp on different replicas converge to the same
+// LWWNode irrespective of the order the LWWSets are merged in
+func TestMerge_SameTimestampAdd(t *testing.T) {
+	lwwsetA := fromNodes(LWWNodeSlice{replicaNode("xx", 10, "a")}, LWWNodeSlice{})
+	lwwsetB := fromNodes(LWWNodeSlice{replicaNode("xx", 10, "b")}, LWWNodeSlice{})
+
+	expectedValue := LWWNodeSlice{replicaNode("xx", 10, "b")}
+
+	assert.Equal(t, expectedValue, Merge(lwwsetA, lwwsetB).AddNodes())
+	assert.Equal(t, expectedValue, Merge(lwwsetB, lwwsetA).AddNodes())
+}
+
+// TestClone checks that a cloned LWWSet is not changed by the
+// changes made to the LWWSet and the other way around
+func TestClone(t *testing.T) {
+	lwwset1 := Initialize(WithClock(NewCounterClock()), WithOpLog(0), WithHistory(0))
+	lwwset1.Addition("xx")
+
+	lwwset2 := lwwset1.Clone()
+	lwwset1.Addition("yy")
+	lwwset2.Removal("xx")
+
+	_, actualValue1 := lwwset1.List()
+	_, actualValue2 := lwwset2.List()
+	ops1, _, _ := lwwset1.OpsSince(0)
+	ops2, _, _ := lwwset2.OpsSince(0)
+	history, _ := lwwset1.History("xx")
+
+	assert.Equal(t, []string{"xx", "yy"}, actualValue1)
+	assert.Equal(t, []string{}, actualValue2)
+	assert.Len(t, ops1, 2)
+	assert.Len(t, ops2, 2)
+	assert.Len(t, history, 1)
+}
+
+// TestAddition_Zero checks that a value can be
+// added in place to the zero LWWSet
+func TestAddition_Zero(t *testing.T) {
+	var lwwset LWWSet
+	lwwset.Addition("xx")
+
+	present, _ := lwwset.Lookup("xx")
+
+	assert.True(t, present)
+}
+
+// TestString checks that a LWWSet is printed with the
+// Nodes of the values added & removed it holds
+func TestString(t *testing.T) {
+	lwwset := Initialize()
+	lwwset.addNode("xx", node("xx", 10))
+	lwwset.removeNode("yy", node("yy", 10))
+
+	expectedValue := fmt.Sprintf("{%v %v}", LWWNodeSlice{node("xx", 10)}, LWWNodeSlice{node("yy", 10)})
+
+	assert.Equal(t, expectedValue, fmt.Sprint(lwwset))
+	assert.Equal(t, "{[] []}", fmt.Sprint(LWWSet{}))
+}
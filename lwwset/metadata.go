@@ -0,0 +1,56 @@
+package lwwset
+
+import "maps"
+
+// Metadata holds the attributes of a value, such as a
+// display name, tags or an owner. It is carried with the
+// added Node of the value so the Metadata of the latest
+// addition of the value wins when LWWSets are merged
+type Metadata map[string]any
+
+// Element is a value present in a
+// LWWSet along with its Metadata
+type Element[T any] struct {
+	Value    T        `json:"value"`
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+// AdditionWithMetadata adds a new unique value to the Add
+// LWWSet along with its Metadata, replacing the Metadata
+// of the value if it was already added
+func (lwwset *KeyedSet[T, K]) AdditionWithMetadata(value T, metadata Metadata) (KeyedSet[T, K], error) {
+	// Return an error if the value passed is nil
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return *lwwset, err
+	}
+
+	lwwset.init()
+
+	// Set = Set U value, refreshing the timestamp
+	// & Metadata if the value was already added
+	node := Node[T]{Value: value, Timestamp: lwwset.now(), Replica: lwwset.store.replica, Metadata: maps.Clone(metadata)}
+	lwwset.addNode(key, node)
+	lwwset.record(Op[T]{ID: newOpID(), Type: AddOp, Node: node})
+
+	// Return the new LWWSet
+	// followed by nil error
+	return *lwwset, nil
+}
+
+// ListWithMetadata returns all the elements present
+// in the LWWSet along with their Metadata
+func (lwwset KeyedSet[T, K]) ListWithMetadata() (KeyedSet[T, K], []Element[T]) {
+	if lwwset.store == nil {
+		return lwwset, []Element[T]{}
+	}
+
+	now := lwwset.read()
+	elements := make([]Element[T], 0, len(lwwset.store.keys))
+	for _, key := range lwwset.store.keys {
+		if node := lwwset.store.entries[key].add; node != nil && !node.expired(now) {
+			elements = append(elements, Element[T]{Value: node.Value, Metadata: maps.Clone(node.Metadata)})
+		}
+	}
+	return lwwset, elements
+}
@@ -0,0 +1,99 @@
+package lwwset
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAdditionWithMetadata checks that the Metadata of a
+// value is replaced by the Metadata of its latest addition
+func TestAdditionWithMetadata(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+
+	lwwset, _ = lwwset.AdditionWithMetadata("xx", Metadata{"owner": "a"})
+	lwwset, _ = lwwset.AdditionWithMetadata("yy", Metadata{"owner": "b"})
+	lwwset, _ = lwwset.AdditionWithMetadata("xx", Metadata{"owner": "c"})
+	lwwset, _ = lwwset.Addition("zz")
+
+	expectedValue := []Element[string]{
+		{Value: "xx", Metadata: Metadata{"owner": "c"}},
+		{Value: "yy", Metadata: Metadata{"owner": "b"}},
+		{Value: "zz"},
+	}
+	_, actualValue := lwwset.ListWithMetadata()
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestAdditionWithMetadata_Removed checks that the
+// Metadata of a removed value is not listed
+func TestAdditionWithMetadata_Removed(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()))
+
+	lwwset, _ = lwwset.AdditionWithMetadata("xx", Metadata{"owner": "a"})
+	lwwset, _ = lwwset.Removal("xx")
+
+	_, actualValue := lwwset.ListWithMetadata()
+
+	assert.Equal(t, []Element[string]{}, actualValue)
+}
+
+// TestAdditionWithMetadata_EmptyValue checks that an
+// empty value cannot be added with Metadata
+func TestAdditionWithMetadata_EmptyValue(t *testing.T) {
+	lwwset := Initialize()
+	_, err := lwwset.AdditionWithMetadata("", Metadata{"owner": "a"})
+
+	assert.Equal(t, errors.New("empty value provided"), err)
+}
+
+// TestAdditionWithMetadata_Copy checks that the Metadata of a
+// value is not changed by changing the Metadata added or listed
+func TestAdditionWithMetadata_Copy(t *testing.T) {
+	metadata := Metadata{"owner": "a"}
+	lwwset := Initialize()
+	lwwset.AdditionWithMetadata("xx", metadata)
+	metadata["owner"] = "b"
+
+	_, elements := lwwset.ListWithMetadata()
+	elements[0].Metadata["owner"] = "c"
+
+	_, elements = lwwset.ListWithMetadata()
+	assert.Equal(t, Metadata{"owner": "a"}, elements[0].Metadata)
+}
+
+// TestMerge_Metadata checks that Merge keeps the Metadata of the latest
+// addition of a value irrespective of the order of merging
+func TestMerge_Metadata(t *testing.T) {
+	lwwset1 := Initialize()
+	lwwset1.addNode("xx", LWWNode{Value: "xx", Timestamp: time.Unix(0, 20), Metadata: Metadata{"owner": "a"}})
+	lwwset2 := Initialize()
+	lwwset2.addNode("xx", LWWNode{Value: "xx", Timestamp: time.Unix(0, 10), Metadata: Metadata{"owner": "b"}})
+
+	expectedValue := []Element[string]{{Value: "xx", Metadata: Metadata{"owner": "a"}}}
+	_, actualValue1 := Merge(lwwset1, lwwset2).ListWithMetadata()
+	_, actualValue2 := Merge(lwwset2, lwwset1).ListWithMetadata()
+
+	assert.Equal(t, expectedValue, actualValue1)
+	assert.Equal(t, expectedValue, actualValue2)
+}
+
+// TestLWWNode_JSON_Metadata checks that the Metadata of a
+// LWWNode is encoded in JSON and decoded back
+func TestLWWNode_JSON_Metadata(t *testing.T) {
+	expectedValue := LWWNode{Value: "xx", Timestamp: time.Unix(0, 10), Metadata: Metadata{"name": "XX", "tags": []any{"a", "b"}}}
+
+	data, err := json.Marshal(expectedValue)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Value":"xx","Metadata":{"name":"XX","tags":["a","b"]},"Timestamp":10}`, string(data))
+
+	var actualValue LWWNode
+	err = json.Unmarshal(data, &actualValue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+}
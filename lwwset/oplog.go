@@ -0,0 +1,169 @@
+package lwwset
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"maps"
+)
+
+// OpType is the type of an operation
+// made on a LWWSet, an add or a remove
+type OpType string
+
+const (
+	// AddOp is the operation adding a value
+	AddOp OpType = "add"
+
+	// RemoveOp is the operation removing a value
+	RemoveOp OpType = "remove"
+)
+
+// ErrInvalidOpType is returned when an operation
+// is neither an AddOp nor a RemoveOp
+var ErrInvalidOpType = errors.New("invalid operation type provided")
+
+// Op is an operation made on a LWWSet, identified by a unique ID
+// so that it is applied only once on every replica it reaches
+type Op[T any] struct {
+	ID   string  `json:"id"`
+	Type OpType  `json:"type"`
+	Node Node[T] `json:"node"`
+}
+
+// DefaultOpLogLimit is the number of operations
+// kept in the op log if no limit is given
+const DefaultOpLogLimit = 10000
+
+// opLog is the append-only log of the operations made on
+// or applied to a LWWSet, keeping only the latest limit
+// operations along with the IDs of the operations kept
+type opLog[T any] struct {
+	ops  []Op[T]
+	seen map[string]struct{}
+	// dropped is the number of operations dropped
+	// from the front of the op log past the limit
+	dropped int
+	limit   int
+}
+
+// WithOpLog records the operations made on & applied to the LWWSet
+// in an append-only log for op-based replication, keeping the latest
+// limit operations, or DefaultOpLogLimit operations if limit is not
+// positive. An operation dropped from the op log is no longer known
+// to have been applied, replicas behind the operations kept are to
+// catch up by merging the entire LWWSet
+func WithOpLog(limit int) Option {
+	return func(options *options) {
+		if limit <= 0 {
+			limit = DefaultOpLogLimit
+		}
+		options.opLogLimit = limit
+	}
+}
+
+// clone returns a copy of the op log
+func (oplog *opLog[T]) clone() *opLog[T] {
+	return &opLog[T]{
+		ops:     append([]Op[T]{}, oplog.ops...),
+		seen:    maps.Clone(oplog.seen),
+		dropped: oplog.dropped,
+		limit:   oplog.limit,
+	}
+}
+
+// newOpID returns a new random operation ID
+func newOpID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// record appends the operation to the op log
+// if enabled, returning false if already seen
+func (lwwset KeyedSet[T, K]) record(op Op[T]) bool {
+	oplog := lwwset.store.opLog
+	if oplog == nil {
+		return true
+	}
+
+	if _, ok := oplog.seen[op.ID]; ok {
+		return false
+	}
+
+	oplog.seen[op.ID] = struct{}{}
+	oplog.ops = append(oplog.ops, op)
+
+	// Drop the oldest operations past the limit, the
+	// slice is reallocated with only the operations
+	// kept once appended past its capacity
+	if drop := len(oplog.ops) - oplog.limit; drop > 0 {
+		for _, dropped := range oplog.ops[:drop] {
+			delete(oplog.seen, dropped.ID)
+		}
+		clear(oplog.ops[:drop])
+		oplog.ops = oplog.ops[drop:]
+		oplog.dropped += drop
+	}
+
+	return true
+}
+
+// OpsSince returns the operations in the op log after the given offset
+// along with the offset to request the next operations from. Offsets
+// count every operation recorded, including the operations dropped
+// past the limit. It reports the op log as truncated if operations
+// after the offset were dropped, or if the op log is not enabled, in
+// which case the operations returned are not every change made to
+// the LWWSet since the offset
+func (lwwset KeyedSet[T, K]) OpsSince(offset int) ([]Op[T], int, bool) {
+	if lwwset.store == nil || lwwset.store.opLog == nil {
+		return []Op[T]{}, 0, true
+	}
+
+	oplog := lwwset.store.opLog
+	end := oplog.dropped + len(oplog.ops)
+	if offset < 0 || offset > end {
+		offset = 0
+	}
+
+	truncated := offset < oplog.dropped
+	offset = max(offset, oplog.dropped)
+
+	return append([]Op[T]{}, oplog.ops[offset-oplog.dropped:]...), end, truncated
+}
+
+// ApplyOps applies the operations received from another replica to
+// the LWWSet, skipping the operations already applied, and returns
+// the LWWSet along with the number of operations applied
+func (lwwset *KeyedSet[T, K]) ApplyOps(ops ...Op[T]) (KeyedSet[T, K], int) {
+	lwwset.init()
+
+	// Clocks such as the HLC move past
+	// the timestamps being applied
+	observer, _ := lwwset.store.clock.(Observer)
+
+	applied := 0
+	for _, op := range ops {
+		key, err := lwwset.validKey(op.Node.Value)
+		if err != nil || !lwwset.record(op) {
+			continue
+		}
+
+		switch op.Type {
+		case AddOp:
+			lwwset.addNode(key, op.Node)
+		case RemoveOp:
+			lwwset.removeNode(key, op.Node)
+		default:
+			continue
+		}
+
+		if observer != nil {
+			observer.Observe(op.Node.Timestamp)
+		}
+		applied++
+	}
+
+	return *lwwset, applied
+}
@@ -0,0 +1,135 @@
+package lwwset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOpsSince checks that a LWWSet with an op log records
+// every addition & removal made on it in order
+func TestOpsSince(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()), WithOpLog(0))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Addition("yy")
+	lwwset, _ = lwwset.Removal("xx")
+
+	ops, offset, _ := lwwset.OpsSince(0)
+
+	assert.Equal(t, 3, offset)
+	assert.Len(t, ops, 3)
+	assert.Equal(t, []OpType{AddOp, AddOp, RemoveOp}, []OpType{ops[0].Type, ops[1].Type, ops[2].Type})
+	assert.Equal(t, node("xx", 3), ops[2].Node)
+	assert.NotEqual(t, ops[0].ID, ops[1].ID)
+
+	ops, offset, _ = lwwset.OpsSince(2)
+
+	assert.Equal(t, 3, offset)
+	assert.Len(t, ops, 1)
+}
+
+// TestOpsSince_Disabled checks that a LWWSet
+// without an op log records no operations
+func TestOpsSince_Disabled(t *testing.T) {
+	lwwset := Initialize()
+	lwwset, _ = lwwset.Addition("xx")
+
+	ops, offset, truncated := lwwset.OpsSince(0)
+
+	assert.Equal(t, 0, offset)
+	assert.Empty(t, ops)
+	assert.True(t, truncated)
+}
+
+// TestOpsSince_Limit checks that the op log keeps only the latest
+// operations up to its limit and reports the operations dropped
+func TestOpsSince_Limit(t *testing.T) {
+	lwwset := Initialize(WithClock(NewCounterClock()), WithOpLog(2))
+
+	lwwset.Addition("xx")
+	lwwset.Addition("yy")
+	lwwset.Removal("xx")
+	lwwset.Addition("zz")
+
+	ops, offset, truncated := lwwset.OpsSince(1)
+
+	assert.Equal(t, 4, offset)
+	assert.Equal(t, []Op[string]{{ID: ops[0].ID, Type: RemoveOp, Node: node("xx", 3)}, {ID: ops[1].ID, Type: AddOp, Node: node("zz", 4)}}, ops)
+	assert.True(t, truncated)
+	assert.Len(t, lwwset.store.opLog.seen, 2)
+
+	ops, offset, truncated = lwwset.OpsSince(3)
+
+	assert.Equal(t, 4, offset)
+	assert.Len(t, ops, 1)
+	assert.False(t, truncated)
+}
+
+// TestApplyOps checks that applying the operations of a LWWSet
+// on a replica brings the replica up to date with the LWWSet
+func TestApplyOps(t *testing.T) {
+	clock := NewCounterClock()
+	lwwset := Initialize(WithClock(clock), WithOpLog(0))
+	replica := Initialize(WithClock(clock), WithOpLog(0))
+
+	lwwset, _ = lwwset.Addition("xx")
+	lwwset, _ = lwwset.Addition("yy")
+	lwwset, _ = lwwset.Removal("xx")
+	replica, _ = replica.Addition("zz")
+
+	ops, _, _ := lwwset.OpsSince(0)
+	replica, applied := replica.ApplyOps(ops...)
+
+	_, list := replica.List()
+	_, offset, _ := replica.OpsSince(0)
+
+	assert.Equal(t, 3, applied)
+	assert.Equal(t, []string{"zz", "yy"}, list)
+	assert.Equal(t, 4, offset)
+}
+
+// TestApplyOps_Duplicate checks that operations already
+// applied to a LWWSet are not applied again
+func TestApplyOps_Duplicate(t *testing.T) {
+	clock := NewCounterClock()
+	lwwset := Initialize(WithClock(clock), WithOpLog(0))
+	replica := Initialize(WithClock(clock), WithOpLog(0))
+
+	lwwset, _ = lwwset.Addition("xx")
+	ops, _, _ := lwwset.OpsSince(0)
+
+	replica, _ = replica.ApplyOps(ops...)
+	replica, applied := replica.ApplyOps(ops...)
+	_, selfApplied := lwwset.ApplyOps(ops...)
+
+	_, offset, _ := replica.OpsSince(0)
+
+	assert.Equal(t, 0, applied)
+	assert.Equal(t, 0, selfApplied)
+	assert.Equal(t, 1, offset)
+}
+
+// TestApplyOps_Relayed checks that operations relayed through another
+// replica's op log are applied only once irrespective of the order
+func TestApplyOps_Relayed(t *testing.T) {
+	clock := NewCounterClock()
+	lwwsetA := Initialize(WithClock(clock), WithOpLog(0))
+	lwwsetB := Initialize(WithClock(clock), WithOpLog(0))
+	lwwsetC := Initialize(WithClock(clock), WithOpLog(0))
+
+	lwwsetA, _ = lwwsetA.Addition("xx")
+	lwwsetA, _ = lwwsetA.Removal("xx")
+	opsA, _, _ := lwwsetA.OpsSince(0)
+	lwwsetB, _ = lwwsetB.ApplyOps(opsA[1], opsA[0])
+
+	opsB, _, _ := lwwsetB.OpsSince(0)
+	lwwsetC, appliedB := lwwsetC.ApplyOps(opsB...)
+	lwwsetC, appliedA := lwwsetC.ApplyOps(opsA...)
+
+	present, _ := lwwsetC.Lookup("xx")
+
+	assert.Equal(t, 2, appliedB)
+	assert.Equal(t, 0, appliedA)
+	assert.False(t, present)
+}
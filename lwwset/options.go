@@ -0,0 +1,43 @@
+package lwwset
+
+// Option configures a LWWSet when it is initialized
+type Option func(*options)
+
+// options holds the configuration
+// applied on Initialize
+type options struct {
+	clock   Clock
+	replica string
+	bias    Bias
+	// opLogLimit is the number of operations kept
+	// in the op log, the op log is disabled if 0
+	opLogLimit int
+	// historyLimit is the number of Records kept
+	// for each value, history is disabled if 0
+	historyLimit int
+}
+
+// WithClock sets the Clock used to timestamp
+// the values added to & removed from the LWWSet
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+// WithReplica sets the ID of the replica owning the LWWSet which
+// breaks ties between two additions, or two removals, of a value
+// with the same timestamp
+func WithReplica(replica string) Option {
+	return func(options *options) {
+		options.replica = replica
+	}
+}
+
+// WithBias sets the Bias of the LWWSet deciding if a value
+// added & removed with the same timestamp is present
+func WithBias(bias Bias) Option {
+	return func(options *options) {
+		options.bias = bias
+	}
+}
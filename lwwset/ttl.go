@@ -0,0 +1,79 @@
+package lwwset
+
+import (
+	"errors"
+	"maps"
+	"time"
+)
+
+// ErrInvalidTTL is returned when a value
+// is added with a TTL which is not positive
+var ErrInvalidTTL = errors.New("invalid ttl provided")
+
+// expired reports if the Node has an
+// expiry at or before the given time
+func (node Node[T]) expired(now time.Time) bool {
+	return !node.Expiry.IsZero() && !now.Before(node.Expiry)
+}
+
+// AdditionWithTTL adds a new unique value to the Add LWWSet
+// which expires after the given TTL. The expiry is carried
+// with the added Node so it is replicated through Merge
+func (lwwset *KeyedSet[T, K]) AdditionWithTTL(value T, ttl time.Duration) (KeyedSet[T, K], error) {
+	return lwwset.AdditionWithTTLAndMetadata(value, ttl, nil)
+}
+
+// AdditionWithTTLAndMetadata adds a new unique value to the Add
+// LWWSet along with its Metadata which expires after the given TTL
+func (lwwset *KeyedSet[T, K]) AdditionWithTTLAndMetadata(value T, ttl time.Duration, metadata Metadata) (KeyedSet[T, K], error) {
+	// Return an error if the value passed is nil
+	key, err := lwwset.validKey(value)
+	if err != nil {
+		return *lwwset, err
+	}
+
+	// Return an error if the TTL passed is not positive
+	if ttl <= 0 {
+		return *lwwset, ErrInvalidTTL
+	}
+
+	lwwset.init()
+
+	// Set = Set U value, refreshing the timestamp, expiry
+	// & Metadata if the value was already added
+	now := lwwset.now()
+	node := Node[T]{Value: value, Timestamp: now, Replica: lwwset.store.replica, Expiry: now.Add(ttl), Metadata: maps.Clone(metadata)}
+	lwwset.addNode(key, node)
+	lwwset.record(Op[T]{ID: newOpID(), Type: AddOp, Node: node})
+
+	// Return the new LWWSet
+	// followed by nil error
+	return *lwwset, nil
+}
+
+// Expire removes the values of the LWWSet whose latest added Node
+// has expired, returning the number of values removed. The removed
+// Node is timestamped with the expiry & replica of the added Node
+// so every replica expiring a value removes it the same way
+func (lwwset *KeyedSet[T, K]) Expire() int {
+	if lwwset.store == nil {
+		return 0
+	}
+
+	now := lwwset.read()
+	expired := []K{}
+	for _, key := range lwwset.store.keys {
+		if node := lwwset.store.entries[key].add; node != nil && node.expired(now) {
+			expired = append(expired, key)
+		}
+	}
+
+	for _, key := range expired {
+		added := lwwset.store.entries[key].add
+		node := Node[T]{Value: added.Value, Timestamp: added.Expiry, Replica: added.Replica}
+		lwwset.removeNode(key, node)
+		lwwset.record(Op[T]{ID: newOpID(), Type: RemoveOp, Node: node})
+	}
+
+	return len(expired)
+}
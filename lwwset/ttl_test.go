@@ -0,0 +1,132 @@
+package lwwset
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAdditionWithTTL checks that a value added with a TTL
+// is present until it expires and absent afterwards
+func TestAdditionWithTTL(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.AdditionWithTTL("xx", 10)
+	lwwset, _ = lwwset.Addition("yy")
+
+	present, _ := lwwset.Lookup("xx")
+	_, list := lwwset.List()
+	assert.True(t, present)
+	assert.Equal(t, []string{"xx", "yy"}, list)
+
+	clock.Advance(10)
+
+	present, _ = lwwset.Lookup("xx")
+	_, list = lwwset.List()
+	assert.False(t, present)
+	assert.Equal(t, []string{"yy"}, list)
+}
+
+// TestAdditionWithTTL_ReAdd checks that a value added again
+// without a TTL after it was added with a TTL does not expire
+func TestAdditionWithTTL_ReAdd(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	lwwset, _ = lwwset.AdditionWithTTL("xx", 10)
+	clock.Advance(1)
+	lwwset, _ = lwwset.Addition("xx")
+	clock.Advance(20)
+
+	present, _ := lwwset.Lookup("xx")
+
+	assert.True(t, present)
+}
+
+// TestAdditionWithTTL_Invalid checks that a value
+// cannot be added with a TTL which is not positive
+func TestAdditionWithTTL_Invalid(t *testing.T) {
+	lwwset := Initialize()
+
+	_, err := lwwset.AdditionWithTTL("xx", 0)
+	assert.Equal(t, errors.New("invalid ttl provided"), err)
+
+	_, err = lwwset.AdditionWithTTL("", time.Second)
+	assert.Equal(t, errors.New("empty value provided"), err)
+}
+
+// TestAdditionWithTTLAndMetadata checks that a value
+// is added with both its Metadata & expiry
+func TestAdditionWithTTLAndMetadata(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock))
+
+	metadata := Metadata{"owner": "a"}
+	lwwset, err := lwwset.AdditionWithTTLAndMetadata("xx", 10, metadata)
+	assert.Nil(t, err)
+	metadata["owner"] = "b"
+
+	_, elements := lwwset.ListWithMetadata()
+	assert.Equal(t, []Element[string]{{Value: "xx", Metadata: Metadata{"owner": "a"}}}, elements)
+	assert.Equal(t, time.Unix(0, 20), lwwset.AddNodes()[0].Expiry)
+
+	clock.Advance(10)
+
+	_, elements = lwwset.ListWithMetadata()
+	assert.Empty(t, elements)
+}
+
+// TestAdditionWithTTL_Merge checks that the
+// expiry of a value is replicated through Merge
+func TestAdditionWithTTL_Merge(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset1 := Initialize(WithClock(clock))
+	lwwset2 := Initialize(WithClock(clock))
+
+	lwwset1, _ = lwwset1.AdditionWithTTL("xx", 10)
+
+	data, err := json.Marshal(lwwset1)
+	assert.Nil(t, err)
+	var decoded LWWSet
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+
+	lwwset2 = lwwset2.Join(decoded)
+	assert.Equal(t, time.Unix(0, 20), lwwset2.AddNodes()[0].Expiry)
+
+	clock.Advance(10)
+
+	present, _ := lwwset2.Lookup("xx")
+	assert.False(t, present)
+}
+
+// TestExpire checks that Expire turns the values expired into removed
+// values timestamped with their expiry so every replica agrees
+func TestExpire(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 10))
+	lwwset := Initialize(WithClock(clock), WithReplica("a"))
+
+	lwwset, _ = lwwset.AdditionWithTTL("xx", 10)
+	lwwset, _ = lwwset.AdditionWithTTL("yy", 20)
+	clock.Advance(15)
+
+	assert.Equal(t, 1, lwwset.Expire())
+	assert.Equal(t, 0, lwwset.Expire())
+	assert.Equal(t, LWWNodeSlice{replicaNode("xx", 20, "a")}, lwwset.RemoveNodes())
+}
+
+// TestLookupAt_TTL checks that LookupAt takes
+// the expiry of the values into account
+func TestLookupAt_TTL(t *testing.T) {
+	lwwset := Initialize(WithClock(NewManualClock(time.Unix(0, 10))), WithHistory(0))
+	lwwset, _ = lwwset.AdditionWithTTL("xx", 10)
+
+	present, _ := lwwset.LookupAt("xx", time.Unix(0, 15))
+	assert.True(t, present)
+
+	present, _ = lwwset.LookupAt("xx", time.Unix(0, 20))
+	assert.False(t, present)
+}
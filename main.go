@@ -26,6 +26,24 @@ func init() {
 func main() {
 	r := handlers.Router()
 
+	// Turn the values expired
+	// into removed values
+	go handlers.Sweep(handlers.GetSweepInterval())
+
+	// Sync with random peers
+	// in the background
+	go handlers.Gossip(handlers.GetGossipInterval(), handlers.GetGossipFanout())
+
+	// Push the changes made by
+	// writes to the peers
+	handlers.StartPush()
+
+	// Removed values are only dropped in op
+	// replication once older than the safe age
+	if handlers.GetReplicationMode() == handlers.OpReplication && handlers.GetTombstoneSafeAge() == 0 {
+		log.Warn("removed values are never dropped in op replication without TOMBSTONE_SAFE_AGE")
+	}
+
 	log.WithFields(log.Fields{
 		"port": PORT,
 	}).Info("started LWWSet node server")
@@ -0,0 +1,86 @@
+package orset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// setType identifies the JSON encoding of an ORSet so
+// that it is not mistaken for another set type's encoding
+const setType = "orset"
+
+// ErrTypeMismatch is returned when decoding
+// the JSON encoding of another set type
+var ErrTypeMismatch = errors.New("encoding of another set type provided")
+
+// jsonEntry is the JSON encoding of
+// the added & removed tags of a value
+type jsonEntry struct {
+	Value  string   `json:"value"`
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// jsonSet is the JSON encoding of an ORSet
+type jsonSet struct {
+	Type    string      `json:"type"`
+	Entries []jsonEntry `json:"entries"`
+}
+
+// tags returns the sorted tags of the tag set
+func tags(set map[string]struct{}) []string {
+	list := make([]string, 0, len(set))
+	for tag := range set {
+		list = append(list, tag)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// MarshalJSON encodes the ORSet with the added
+// & removed tags of each of its values
+func (orset ORSet) MarshalJSON() ([]byte, error) {
+	encoded := jsonSet{Type: setType, Entries: []jsonEntry{}}
+
+	if orset.store != nil {
+		for _, value := range orset.store.values {
+			valueEntry := orset.store.entries[value]
+			encoded.Entries = append(encoded.Entries, jsonEntry{
+				Value:  value,
+				Add:    tags(valueEntry.add),
+				Remove: tags(valueEntry.remove),
+			})
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes an ORSet encoded by MarshalJSON
+// and refuses the encoding of another set type
+func (orset *ORSet) UnmarshalJSON(data []byte) error {
+	var decoded jsonSet
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Type != setType {
+		return fmt.Errorf("%w: %s", ErrTypeMismatch, decoded.Type)
+	}
+
+	*orset = Initialize()
+	for _, valueEntry := range decoded.Entries {
+		orset.entry(valueEntry.Value)
+		for _, tag := range valueEntry.Remove {
+			orset.removeTag(valueEntry.Value, tag)
+		}
+		for _, tag := range valueEntry.Add {
+			orset.addTag(valueEntry.Value, tag)
+		}
+	}
+
+	return nil
+}
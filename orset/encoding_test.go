@@ -0,0 +1,50 @@
+package orset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestORSet_MarshalJSON checks that an ORSet is encoded in
+// JSON with the added & removed tags of each of its values
+func TestORSet_MarshalJSON(t *testing.T) {
+	set := Initialize()
+	set.addTag("xx", "a")
+	set.addTag("yy", "b")
+	set.removeTag("yy", "b")
+
+	expectedValue := `{"type":"orset","entries":[{"value":"xx","add":["a"],"remove":[]},{"value":"yy","add":[],"remove":["b"]}]}`
+	actualValue, err := json.Marshal(set)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, string(actualValue))
+}
+
+// TestORSet_JSON checks that an ORSet encoded in
+// JSON is decoded back to the same ORSet
+func TestORSet_JSON(t *testing.T) {
+	expectedValue := Initialize()
+	expectedValue.Addition("xx")
+	expectedValue, _ = expectedValue.Addition("yy")
+	expectedValue, _ = expectedValue.Removal("yy")
+
+	data, err := json.Marshal(expectedValue)
+	assert.Nil(t, err)
+
+	var actualValue ORSet
+	err = json.Unmarshal(data, &actualValue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue.store, actualValue.store)
+}
+
+// TestORSet_UnmarshalJSON_Type checks that the JSON
+// encoding of another set type is not decoded
+func TestORSet_UnmarshalJSON_Type(t *testing.T) {
+	var set ORSet
+	err := json.Unmarshal([]byte(`{"add":[{"Value":"xx","Timestamp":10}],"remove":[],"bias":"remove"}`), &set)
+
+	assert.NotNil(t, err)
+}
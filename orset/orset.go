@@ -0,0 +1,211 @@
+package orset
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// package orset implements the ORSet (Observed-Remove Set) CRDT data type along with the functionality
+// to append, remove, list & lookup values in an ORSet. Each addition of a value is identified by a unique
+// tag and a removal only removes the tags it has observed, so a concurrent addition of a value is never
+// lost to a removal. It also provides the functionality to merge multiple ORSets together and a utility
+// function to clear an ORSet used in tests
+
+// ORSet is the ORSet CRDT data type over string values
+// It is implemented by indexing for each value the tags
+// of its additions not removed yet & the tags removed
+// An ORSet is a handle to its storage, copies of an
+// ORSet share the same values. The methods changing an
+// ORSet have pointer receivers & change it in place
+type ORSet struct {
+	store *store
+}
+
+// store is the storage shared
+// by the copies of an ORSet
+type store struct {
+	// entries indexes the added &
+	// removed tags by their value
+	entries map[string]*entry
+	// values are the values of the entries
+	// in the order they were first seen
+	values []string
+}
+
+// entry holds the tags of the additions of a value
+// not removed yet & the tags of the removed additions
+type entry struct {
+	add    map[string]struct{}
+	remove map[string]struct{}
+}
+
+// ErrEmptyValue is returned when an
+// empty value is added, removed or looked up
+var ErrEmptyValue = errors.New("empty value provided")
+
+// Initialize returns a new empty ORSet
+func Initialize() ORSet {
+	return ORSet{
+		store: &store{
+			entries: map[string]*entry{},
+			values:  []string{},
+		},
+	}
+}
+
+// init initializes the storage of
+// a zero value ORSet if not present
+func (orset *ORSet) init() ORSet {
+	if orset.store == nil {
+		*orset = Initialize()
+	}
+	return *orset
+}
+
+// newTag returns a new random tag
+// identifying an addition of a value
+func newTag() string {
+	tag := make([]byte, 16)
+	rand.Read(tag)
+	return hex.EncodeToString(tag)
+}
+
+// entry returns the entry of the
+// value, creating it if not present
+func (orset ORSet) entry(value string) *entry {
+	valueEntry, ok := orset.store.entries[value]
+	if !ok {
+		valueEntry = &entry{add: map[string]struct{}{}, remove: map[string]struct{}{}}
+		orset.store.entries[value] = valueEntry
+		orset.store.values = append(orset.store.values, value)
+	}
+	return valueEntry
+}
+
+// addTag adds the tag of an addition of the
+// value unless the tag was already removed
+func (orset ORSet) addTag(value string, tag string) {
+	valueEntry := orset.entry(value)
+	if _, ok := valueEntry.remove[tag]; !ok {
+		valueEntry.add[tag] = struct{}{}
+	}
+}
+
+// removeTag removes the tag of an
+// addition of the value for good
+func (orset ORSet) removeTag(value string, tag string) {
+	valueEntry := orset.entry(value)
+	delete(valueEntry.add, tag)
+	valueEntry.remove[tag] = struct{}{}
+}
+
+// Addition adds a new unique tag of the value to the ORSet
+func (orset *ORSet) Addition(value string) (ORSet, error) {
+	// Return an error if the value passed is nil
+	if value == "" {
+		return *orset, ErrEmptyValue
+	}
+
+	orset.init().addTag(value, newTag())
+	return *orset, nil
+}
+
+// Removal removes the tags of the value
+// observed by the ORSet from it
+func (orset *ORSet) Removal(value string) (ORSet, error) {
+	// Return an error if the value passed is nil
+	if value == "" {
+		return *orset, ErrEmptyValue
+	}
+
+	valueEntry, ok := orset.init().store.entries[value]
+	if !ok {
+		return *orset, nil
+	}
+
+	for tag := range valueEntry.add {
+		orset.removeTag(value, tag)
+	}
+	return *orset, nil
+}
+
+// Lookup returns if the given value has
+// an addition not removed in the ORSet
+func (orset ORSet) Lookup(value string) (bool, error) {
+	// Return an error if the value passed is nil
+	if value == "" {
+		return false, ErrEmptyValue
+	}
+
+	if orset.store == nil {
+		return false, nil
+	}
+
+	valueEntry, ok := orset.store.entries[value]
+	return ok && len(valueEntry.add) != 0, nil
+}
+
+// List returns all the values present in the ORSet
+func (orset ORSet) List() (ORSet, []string) {
+	list := []string{}
+
+	if orset.store == nil {
+		return orset, list
+	}
+
+	for _, value := range orset.store.values {
+		if len(orset.store.entries[value].add) != 0 {
+			list = append(list, value)
+		}
+	}
+
+	return orset, list
+}
+
+// String returns the values present in
+// the ORSet, as printed in the logs
+func (orset ORSet) String() string {
+	_, values := orset.List()
+	return fmt.Sprint(values)
+}
+
+// Join merges the given ORSets into the ORSet in place
+// taking the union of their added & removed tags
+func (orset *ORSet) Join(sets ...ORSet) ORSet {
+	orset.init()
+
+	for _, set := range sets {
+		if set.store == nil {
+			continue
+		}
+
+		for _, value := range set.store.values {
+			valueEntry := set.store.entries[value]
+			for tag := range valueEntry.remove {
+				orset.removeTag(value, tag)
+			}
+			for tag := range valueEntry.add {
+				orset.addTag(value, tag)
+			}
+		}
+	}
+
+	return *orset
+}
+
+// Merge conflict resolves multiple ORSets
+// and returns a new merged ORSet
+func Merge(sets ...ORSet) ORSet {
+	orset := Initialize()
+	return orset.Join(sets...)
+}
+
+// Clear is a utility function used
+// only for tests to empty the
+// contents of a given ORSet
+func Clear() ORSet {
+	orset := Initialize()
+	return orset
+}
@@ -0,0 +1,201 @@
+package orset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orset ORSet
+)
+
+func init() {
+	orset = Initialize()
+}
+
+// TestList checks the basic functionality of ORSet List()
+// List() should return all unique values added to the ORSet
+func TestList(t *testing.T) {
+	orset, _ = orset.Addition("xx")
+	orset, _ = orset.Addition("yy")
+	orset, _ = orset.Addition("xx")
+
+	expectedValue := []string{"xx", "yy"}
+	_, actualValue := orset.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+
+	orset = Clear()
+}
+
+// TestList_RemoveValue checks the functionality of ORSet List() when
+// values are added & removed it should return the values still present
+func TestList_RemoveValue(t *testing.T) {
+	orset, _ = orset.Addition("xx")
+	orset, _ = orset.Addition("yy")
+	orset, _ = orset.Removal("xx")
+	orset, _ = orset.Removal("zz")
+
+	expectedValue := []string{"yy"}
+	_, actualValue := orset.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+
+	orset = Clear()
+}
+
+// TestList_ReAddValue checks the functionality of ORSet List()
+// when a value is added again after it got removed
+func TestList_ReAddValue(t *testing.T) {
+	orset, _ = orset.Addition("xx")
+	orset, _ = orset.Removal("xx")
+	orset, _ = orset.Addition("xx")
+
+	expectedValue := []string{"xx"}
+	_, actualValue := orset.List()
+
+	assert.Equal(t, expectedValue, actualValue)
+
+	orset = Clear()
+}
+
+// TestLookup checks the basic functionality of ORSet Lookup()
+func TestLookup(t *testing.T) {
+	orset, _ = orset.Addition("xx")
+	orset, _ = orset.Addition("yy")
+	orset, _ = orset.Removal("yy")
+
+	present, err := orset.Lookup("xx")
+	assert.Nil(t, err)
+	assert.True(t, present)
+
+	present, err = orset.Lookup("yy")
+	assert.Nil(t, err)
+	assert.False(t, present)
+
+	present, err = orset.Lookup("zz")
+	assert.Nil(t, err)
+	assert.False(t, present)
+
+	orset = Clear()
+}
+
+// TestEmptyValue checks that an empty value cannot
+// be added to, removed from or looked up in an ORSet
+func TestEmptyValue(t *testing.T) {
+	expectedError := errors.New("empty value provided")
+
+	_, additionError := orset.Addition("")
+	_, removalError := orset.Removal("")
+	_, lookupError := orset.Lookup("")
+
+	assert.Equal(t, expectedError, additionError)
+	assert.Equal(t, expectedError, removalError)
+	assert.Equal(t, expectedError, lookupError)
+}
+
+// TestZeroValue checks that the zero value
+// of an ORSet can be used as an empty ORSet
+func TestZeroValue(t *testing.T) {
+	var set ORSet
+
+	_, list := set.List()
+	assert.Equal(t, []string{}, list)
+
+	set, _ = set.Addition("xx")
+	present, _ := set.Lookup("xx")
+	assert.True(t, present)
+}
+
+// TestMerge_ConcurrentAdd checks that a value added concurrently
+// with its removal on another ORSet is present after a Merge
+// irrespective of the order of merging
+func TestMerge_ConcurrentAdd(t *testing.T) {
+	orset1 := Initialize()
+	orset1.Addition("xx")
+	orset2 := Merge(orset1)
+
+	orset1, _ = orset1.Addition("xx")
+	orset2, _ = orset2.Removal("xx")
+
+	expectedValue := []string{"xx"}
+	_, actualValue1 := Merge(orset1, orset2).List()
+	_, actualValue2 := Merge(orset2, orset1).List()
+
+	assert.Equal(t, expectedValue, actualValue1)
+	assert.Equal(t, expectedValue, actualValue2)
+}
+
+// TestMerge_ObservedRemove checks that a value removed after its
+// additions were observed stays removed after a Merge
+func TestMerge_ObservedRemove(t *testing.T) {
+	orset1 := Initialize()
+	orset1.Addition("xx")
+	orset2 := Merge(orset1)
+
+	orset2, _ = orset2.Removal("xx")
+
+	expectedValue := []string{}
+	_, actualValue1 := Merge(orset1, orset2).List()
+	_, actualValue2 := Merge(orset2, orset1).List()
+
+	assert.Equal(t, expectedValue, actualValue1)
+	assert.Equal(t, expectedValue, actualValue2)
+}
+
+// TestMerge_Idempotent checks that merging an
+// ORSet with itself does not change its values
+func TestMerge_Idempotent(t *testing.T) {
+	orset1 := Initialize()
+	orset1.Addition("xx")
+	orset1, _ = orset1.Addition("yy")
+	orset1, _ = orset1.Removal("yy")
+
+	_, expectedValue := orset1.List()
+	_, actualValue := Merge(orset1, orset1).List()
+
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+// TestMerge_Copy checks that Merge returns a new
+// ORSet without changing the ORSets merged
+func TestMerge_Copy(t *testing.T) {
+	orset1 := Initialize()
+	orset1.Addition("xx")
+	orset2 := Initialize()
+	orset2.Addition("yy")
+
+	merged := Merge(orset1, orset2)
+	merged, _ = merged.Removal("xx")
+
+	present, _ := orset1.Lookup("xx")
+	assert.True(t, present)
+}
+
+// TestAddition_Zero checks that a value can be added
+// & removed in place in the zero ORSet, as in a LWWSet
+func TestAddition_Zero(t *testing.T) {
+	var orset ORSet
+	orset.Addition("xx")
+	orset.Addition("yy")
+	orset.Removal("yy")
+
+	_, actualValue := orset.List()
+
+	assert.Equal(t, []string{"xx"}, actualValue)
+}
+
+// TestJoin_InPlace checks that Join merges the
+// ORSets into the ORSet in place
+func TestJoin_InPlace(t *testing.T) {
+	var orset1 ORSet
+	orset2 := Initialize()
+	orset2.Addition("xx")
+
+	orset1.Join(orset2)
+	present, _ := orset1.Lookup("xx")
+
+	assert.True(t, present)
+}